@@ -0,0 +1,370 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Alias documents.
+//
+// An alias document maps IDs to their aliases, in the shape of
+// map[ID][]string, and is the format of --chain-aliases-file:
+//
+//	{
+//		"P": ["platform"],
+//		"2oYMBNV4eNHyqk2fjjV5nVQLDbtmNJzq5s3qs3Lo6ftnC6FByM": ["swap", "x-chain"]
+//	}
+//
+// Keys are anything FromString accepts: CB58 IDs and native chain names.
+// JSON is read through a YAML parser, so the same document may be written
+// as YAML, and every problem can be reported with its line.
+
+var (
+	// ErrAliasConflict is wrapped by the *AliasConflictError LoadAliases
+	// returns when it rejects a document.
+	ErrAliasConflict = errors.New("alias conflict")
+
+	errMalformedAliasDocument = errors.New("malformed alias document")
+	errCannotExportAliases    = errors.New("aliaser does not support export")
+)
+
+// AliasConflict is one problem found in an alias document.
+type AliasConflict struct {
+	// Line is the 1-based line of the offending key or alias.
+	Line int
+	// Key is the ID key as written in the document.
+	Key string
+	// Alias is the offending alias, or empty if the problem is with the
+	// key itself.
+	Alias string
+	Err   error
+}
+
+func (c AliasConflict) Error() string {
+	if c.Alias == "" {
+		return fmt.Sprintf("line %d: key %q: %v", c.Line, c.Key, c.Err)
+	}
+	return fmt.Sprintf("line %d: key %q: alias %q: %v", c.Line, c.Key, c.Alias, c.Err)
+}
+
+// AliasConflictError lists every problem that made LoadAliases reject a
+// document, in document order.
+type AliasConflictError struct {
+	Conflicts []AliasConflict
+}
+
+func (e *AliasConflictError) Error() string {
+	msgs := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		msgs[i] = c.Error()
+	}
+	return fmt.Sprintf("%s: %d found: %s", ErrAliasConflict, len(e.Conflicts), strings.Join(msgs, "; "))
+}
+
+// Unwrap returns ErrAliasConflict followed by the error of every conflict.
+func (e *AliasConflictError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Conflicts)+1)
+	errs = append(errs, ErrAliasConflict)
+	for _, c := range e.Conflicts {
+		errs = append(errs, c.Err)
+	}
+	return errs
+}
+
+// aliasEntry is one alias to be given to an ID by a batch.
+type aliasEntry struct {
+	id    ID
+	alias string
+}
+
+// aliasBatcher is implemented by the aliasers of this package, which can
+// check and apply a batch of aliases under one lock.
+type aliasBatcher interface {
+	// aliasBatch gives every entry its alias, or none if any entry
+	// conflicts or [checkOnly] is set. An entry whose alias is already
+	// mapped to its ID is skipped. On conflict, errs has a non-nil error at
+	// the index of each conflicting entry; err reports any other failure.
+	aliasBatch(entries []aliasEntry, checkOnly bool) (errs []error, err error)
+}
+
+// aliasSnapshotter is implemented by the aliasers of this package.
+type aliasSnapshotter interface {
+	// aliasSnapshot returns a copy of every ID's aliases, in order.
+	aliasSnapshot() map[ID][]string
+}
+
+// LoadAliases reads an alias document from [r] and gives each listed ID its
+// aliases in [a], in document order.
+//
+// The document is checked as a whole before anything is applied: every
+// malformed key or alias, alias listed twice, alias already mapped to a
+// different ID, and alias refused by the aliaser's AliasPolicy is reported
+// in one *AliasConflictError, and none of the aliases are applied. Aliases
+// already mapped to the same ID are skipped, so loading a document twice is
+// harmless.
+//
+// The check and the update are atomic for the aliasers of this package. For
+// other AliaserWriters, existing mappings are checked through Lookup if [a]
+// is also an AliaserReader, and the aliases are then applied one by one.
+func LoadAliases(r io.Reader, a AliaserWriter) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return fmt.Errorf("couldn't parse alias document: %w", err)
+	}
+
+	// The well-formed entries are checked against [a] even if the document
+	// has other problems, so that every conflict is reported at once.
+	entries, sources, conflicts := parseAliasDocument(&doc)
+	errs, err := applyAliases(a, entries, len(conflicts) > 0)
+	for i, entryErr := range errs {
+		if entryErr != nil {
+			conflicts = append(conflicts, sources[i].conflict(entryErr))
+		}
+	}
+	if len(conflicts) > 0 {
+		slices.SortStableFunc(conflicts, func(x, y AliasConflict) int {
+			return x.Line - y.Line
+		})
+		return &AliasConflictError{Conflicts: conflicts}
+	}
+	return err
+}
+
+// aliasSource is where an aliasEntry came from in a document.
+type aliasSource struct {
+	line  int
+	key   string
+	alias string
+}
+
+func (s aliasSource) conflict(err error) AliasConflict {
+	return AliasConflict{
+		Line:  s.line,
+		Key:   s.key,
+		Alias: s.alias,
+		Err:   err,
+	}
+}
+
+// parseAliasDocument returns the well-formed entries of [doc] with their
+// sources, and every problem in [doc].
+func parseAliasDocument(doc *yaml.Node) ([]aliasEntry, []aliasSource, []AliasConflict) {
+	if doc.Kind == 0 || len(doc.Content) == 0 {
+		return nil, nil, nil // empty document
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, nil, []AliasConflict{{
+			Line: root.Line,
+			Err:  fmt.Errorf("%w: expected a mapping from IDs to alias lists", errMalformedAliasDocument),
+		}}
+	}
+
+	var (
+		entries   []aliasEntry
+		sources   []aliasSource
+		conflicts []AliasConflict
+		seen      = make(map[string]aliasSource)
+	)
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		keyNode, valueNode := root.Content[i], root.Content[i+1]
+		key := keyNode.Value
+		if keyNode.Kind != yaml.ScalarNode {
+			conflicts = append(conflicts, AliasConflict{
+				Line: keyNode.Line,
+				Err:  fmt.Errorf("%w: key must be an ID", errMalformedAliasDocument),
+			})
+			continue
+		}
+		id, err := FromString(key)
+		if err != nil {
+			conflicts = append(conflicts, AliasConflict{
+				Line: keyNode.Line,
+				Key:  key,
+				Err:  fmt.Errorf("%w: %w", errMalformedAliasDocument, err),
+			})
+			continue
+		}
+
+		var aliasNodes []*yaml.Node
+		switch {
+		case valueNode.Kind == yaml.SequenceNode:
+			aliasNodes = valueNode.Content
+		case valueNode.Kind == yaml.ScalarNode && valueNode.Tag == "!!null":
+		default:
+			conflicts = append(conflicts, AliasConflict{
+				Line: valueNode.Line,
+				Key:  key,
+				Err:  fmt.Errorf("%w: aliases must be a list", errMalformedAliasDocument),
+			})
+			continue
+		}
+
+		for _, n := range aliasNodes {
+			source := aliasSource{line: n.Line, key: key, alias: n.Value}
+			if n.Kind != yaml.ScalarNode || n.Tag == "!!null" {
+				source.alias = ""
+				conflicts = append(conflicts, source.conflict(
+					fmt.Errorf("%w: alias must be a string", errMalformedAliasDocument),
+				))
+				continue
+			}
+			if prev, ok := seen[n.Value]; ok {
+				conflicts = append(conflicts, source.conflict(
+					fmt.Errorf("%w: %s is also listed on line %d", ErrAliasAlreadyMapped, n.Value, prev.line),
+				))
+				continue
+			}
+			seen[n.Value] = source
+			entries = append(entries, aliasEntry{id: id, alias: n.Value})
+			sources = append(sources, source)
+		}
+	}
+	return entries, sources, conflicts
+}
+
+// applyAliases applies [entries] to [a], atomically if [a] supports it.
+// If [checkOnly] is set, the entries are checked but not applied. See
+// aliasBatcher for the results.
+func applyAliases(a AliaserWriter, entries []aliasEntry, checkOnly bool) ([]error, error) {
+	if b, ok := a.(aliasBatcher); ok {
+		return b.aliasBatch(entries, checkOnly)
+	}
+
+	var (
+		errs   = make([]error, len(entries))
+		failed = false
+		apply  = make([]bool, len(entries))
+	)
+	reader, canRead := a.(AliaserReader)
+	for i, e := range entries {
+		apply[i] = true
+		if !canRead {
+			continue
+		}
+		id, err := reader.Lookup(e.alias)
+		switch {
+		case err != nil:
+		case id == e.id:
+			apply[i] = false
+		default:
+			errs[i] = fmt.Errorf("%w: %s is mapped to %s", ErrAliasAlreadyMapped, e.alias, id)
+			failed = true
+		}
+	}
+	if failed || checkOnly {
+		return errs, nil
+	}
+	for i, e := range entries {
+		if !apply[i] {
+			continue
+		}
+		if err := a.Alias(e.id, e.alias); err != nil {
+			errs[i] = err
+			return errs, nil
+		}
+	}
+	return nil, nil
+}
+
+// ExportAliases writes every alias of [a] to [w] as a JSON alias document
+// that LoadAliases accepts. Keys are ID strings, sorted; the aliases of
+// each ID keep their order, so the primary alias stays first. [a] must be
+// an aliaser of this package, since AliaserReader cannot list its IDs.
+func ExportAliases(a AliaserReader, w io.Writer) error {
+	s, ok := a.(aliasSnapshotter)
+	if !ok {
+		return fmt.Errorf("%w: %T", errCannotExportAliases, a)
+	}
+	b, err := json.MarshalIndent(s.aliasSnapshot(), "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+func (a *aliaser) aliasBatch(entries []aliasEntry, checkOnly bool) ([]error, error) {
+	defer a.events.deliver()
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	var (
+		errs   = make([]error, len(entries))
+		failed = false
+		added  = make([]aliasEntry, 0, len(entries))
+		// keys maps the key of each added alias to its index in entries,
+		// to catch entries that only clash under normalization.
+		keys = make(map[string]int, len(entries))
+	)
+	for i, e := range entries {
+		key := a.key(e.alias)
+		if id, exists := a.dealias[key]; exists {
+			if id != e.id || a.spelling(id, key) != e.alias {
+				errs[i] = fmt.Errorf("%w: %s is mapped to %s", ErrAliasAlreadyMapped, a.spelling(id, key), id)
+				failed = true
+			}
+			continue
+		}
+		if j, exists := keys[key]; exists {
+			errs[i] = fmt.Errorf("%w: %s clashes with %s", ErrAliasAlreadyMapped, e.alias, entries[j].alias)
+			failed = true
+			continue
+		}
+		if err := a.checkPolicy(e.id, e.alias); err != nil {
+			errs[i] = err
+			failed = true
+			continue
+		}
+		keys[key] = i
+		added = append(added, e)
+	}
+	if failed || checkOnly {
+		return errs, nil
+	}
+
+	if a.store != nil && len(added) > 0 {
+		batch := make([]AliasStoreWrite, len(added))
+		for i, e := range added {
+			batch[i] = AliasStoreWrite{
+				Key:   []byte(e.alias),
+				Value: aliasRecord(a.nextSeq+uint64(i), e.id),
+			}
+		}
+		if err := a.store.Write(batch); err != nil {
+			return nil, fmt.Errorf("couldn't persist aliases: %w", err)
+		}
+		a.nextSeq += uint64(len(added))
+	}
+	for _, e := range added {
+		a.dealias[a.key(e.alias)] = e.id
+		a.aliases[e.id] = append(a.aliases[e.id], e.alias)
+		a.events.queue(AliasAdded, e.id, e.alias)
+	}
+	return nil, nil
+}
+
+func (a *aliaser) aliasSnapshot() map[ID][]string {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	snapshot := make(map[ID][]string, len(a.aliases))
+	for id, aliases := range a.aliases {
+		snapshot[id] = slices.Clone(aliases)
+	}
+	return snapshot
+}
@@ -0,0 +1,242 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// readerWriter is an Aliaser that only exposes the AliaserReader and
+// AliaserWriter methods, to exercise the non-atomic LoadAliases path.
+type readerWriter struct {
+	AliaserReader
+	AliaserWriter
+}
+
+func TestLoadAliasesJSON(t *testing.T) {
+	require := require.New(t)
+
+	id := ID{'B', 'r', 'u', 'c', 'e'}
+	doc := `{
+	"P": ["platform", "p-chain"],
+	"` + id.String() + `": ["Batman"],
+	"X": []
+}`
+	a := NewAliaser()
+	require.NoError(LoadAliases(strings.NewReader(doc), a))
+
+	aliases, err := a.Aliases(PChainID)
+	require.NoError(err)
+	require.Equal([]string{"platform", "p-chain"}, aliases)
+	require.Equal("Batman", a.PrimaryAliasOrDefault(id))
+
+	// Reloading the same document is a no-op.
+	require.NoError(LoadAliases(strings.NewReader(doc), a))
+	aliases, err = a.Aliases(PChainID)
+	require.NoError(err)
+	require.Equal([]string{"platform", "p-chain"}, aliases)
+}
+
+func TestLoadAliasesYAML(t *testing.T) {
+	require := require.New(t)
+
+	doc := `
+c:
+  - contract
+  - evm
+Q: ~
+`
+	a := NewAliaser()
+	require.NoError(LoadAliases(strings.NewReader(doc), a))
+	id, err := a.Lookup("evm")
+	require.NoError(err)
+	require.Equal(CChainID, id)
+}
+
+func TestLoadAliasesReportsEveryConflict(t *testing.T) {
+	require := require.New(t)
+
+	a := NewAliaser()
+	require.NoError(a.Alias(XChainID, "swap"))
+	doc := `{
+	"P": ["platform", "platform"],
+	"not an id": ["bad"],
+	"C": "evm",
+	"Q": [["nested"]]
+}`
+	err := LoadAliases(strings.NewReader(doc), a)
+	require.ErrorIs(err, ErrAliasConflict)
+
+	var conflictErr *AliasConflictError
+	require.ErrorAs(err, &conflictErr)
+	require.Len(conflictErr.Conflicts, 4)
+	expected := []struct {
+		line  int
+		key   string
+		alias string
+		err   error
+	}{
+		{2, "P", "platform", ErrAliasAlreadyMapped},
+		{3, "not an id", "", errMalformedAliasDocument},
+		{4, "C", "", errMalformedAliasDocument},
+		{5, "Q", "", errMalformedAliasDocument},
+	}
+	for i, e := range expected {
+		c := conflictErr.Conflicts[i]
+		require.Equal(e.line, c.Line, "conflict %d", i)
+		require.Equal(e.key, c.Key, "conflict %d", i)
+		require.Equal(e.alias, c.Alias, "conflict %d", i)
+		require.ErrorIs(c.Err, e.err, "conflict %d", i)
+	}
+
+	_, err = a.Lookup("platform")
+	require.ErrorIs(err, ErrNoIDWithAlias, "a rejected document must not be applied")
+}
+
+// TestLoadAliasesChecksDespiteMalformedKeys checks that a malformed key
+// does not hide conflicts with the aliaser in the rest of the document.
+func TestLoadAliasesChecksDespiteMalformedKeys(t *testing.T) {
+	for name, wrap := range map[string]func(Aliaser) AliaserWriter{
+		"aliaser":       func(a Aliaser) AliaserWriter { return a },
+		"reader-writer": func(a Aliaser) AliaserWriter { return readerWriter{a, a} },
+	} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			a := NewAliaserWithPolicy(AliasPolicy{Reserved: []string{"admin"}})
+			require.NoError(a.Alias(XChainID, "taken"))
+			doc := `{
+	"bad!": ["x"],
+	"P": ["taken", "platform"],
+	"C": ["admin"]
+}`
+			err := LoadAliases(strings.NewReader(doc), wrap(a))
+			var conflictErr *AliasConflictError
+			require.ErrorAs(err, &conflictErr)
+
+			expected := []struct {
+				line  int
+				key   string
+				alias string
+				err   error
+			}{
+				{2, "bad!", "", errMalformedAliasDocument},
+				{3, "P", "taken", ErrAliasAlreadyMapped},
+				{4, "C", "admin", ErrAliasReserved},
+			}
+			if name == "reader-writer" {
+				// Without an aliasBatcher, the policy is only checked when
+				// an alias is applied.
+				expected = expected[:2]
+			}
+			require.Len(conflictErr.Conflicts, len(expected))
+			for i, e := range expected {
+				c := conflictErr.Conflicts[i]
+				require.Equal(e.line, c.Line, "conflict %d", i)
+				require.Equal(e.key, c.Key, "conflict %d", i)
+				require.Equal(e.alias, c.Alias, "conflict %d", i)
+				require.ErrorIs(c.Err, e.err, "conflict %d", i)
+			}
+
+			_, err = a.Lookup("platform")
+			require.ErrorIs(err, ErrNoIDWithAlias, "a rejected document must not be applied")
+		})
+	}
+}
+
+func TestLoadAliasesIsAtomic(t *testing.T) {
+	for name, wrap := range map[string]func(Aliaser) AliaserWriter{
+		"aliaser":       func(a Aliaser) AliaserWriter { return a },
+		"reader-writer": func(a Aliaser) AliaserWriter { return readerWriter{a, a} },
+	} {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			a := NewAliaser()
+			require.NoError(a.Alias(XChainID, "swap"))
+			doc := `
+P: [platform]
+C: [evm, swap]
+`
+			err := LoadAliases(strings.NewReader(doc), wrap(a))
+			var conflictErr *AliasConflictError
+			require.ErrorAs(err, &conflictErr)
+			require.Equal([]AliasConflict{{
+				Line:  3,
+				Key:   "C",
+				Alias: "swap",
+				Err:   conflictErr.Conflicts[0].Err,
+			}}, conflictErr.Conflicts)
+			require.ErrorIs(conflictErr.Conflicts[0].Err, ErrAliasAlreadyMapped)
+
+			for _, alias := range []string{"platform", "evm"} {
+				_, err := a.Lookup(alias)
+				require.ErrorIs(err, ErrNoIDWithAlias)
+			}
+		})
+	}
+}
+
+func TestLoadAliasesMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+	}{
+		{"syntax error", `{"P": [`},
+		{"not a mapping", `["platform"]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := LoadAliases(strings.NewReader(tt.doc), NewAliaser())
+			require.Error(t, err) //nolint:forbidigo // yaml syntax errors are not typed
+		})
+	}
+
+	require.NoError(t, LoadAliases(strings.NewReader(""), NewAliaser()))
+}
+
+func TestLoadAliasesPersists(t *testing.T) {
+	require := require.New(t)
+
+	store := NewMemoryAliasStore()
+	a, err := NewPersistentAliaser(store)
+	require.NoError(err)
+	events, cancel := a.Watch(2)
+	defer cancel()
+
+	require.NoError(LoadAliases(strings.NewReader(`{"P": ["platform", "p-chain"]}`), a))
+	require.Equal(AliasEvent{Seq: 1, Type: AliasAdded, ID: PChainID, Alias: "platform"}, <-events)
+	require.Equal(AliasEvent{Seq: 2, Type: AliasAdded, ID: PChainID, Alias: "p-chain"}, <-events)
+
+	reloaded, err := NewPersistentAliaser(store)
+	require.NoError(err)
+	aliases, err := reloaded.Aliases(PChainID)
+	require.NoError(err)
+	require.Equal([]string{"platform", "p-chain"}, aliases)
+}
+
+func TestExportAliases(t *testing.T) {
+	require := require.New(t)
+
+	id := ID{'B', 'r', 'u', 'c', 'e'}
+	a := NewAliaser()
+	require.NoError(a.Alias(id, "Dark Knight"))
+	require.NoError(a.Alias(id, "Batman"))
+	require.NoError(a.Alias(PChainID, "platform"))
+
+	var buf bytes.Buffer
+	require.NoError(ExportAliases(a, &buf))
+
+	imported := NewAliaser()
+	require.NoError(LoadAliases(&buf, imported))
+	require.Equal(a.(aliasSnapshotter).aliasSnapshot(), imported.(aliasSnapshotter).aliasSnapshot())
+	require.Equal("Dark Knight", imported.PrimaryAliasOrDefault(id))
+
+	err := ExportAliases(readerWriter{a, a}, &buf)
+	require.ErrorIs(err, errCannotExportAliases)
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+var (
+	_ AliaserEditor = (*aliaser)(nil)
+
+	ErrAliasNotOfID = errors.New("alias is not an alias of ID")
+)
+
+// AliaserEditor extends AliaserWriter with edits to the aliases an ID
+// already has. Each edit is atomic: if it returns an error, no alias has
+// changed.
+type AliaserEditor interface {
+	AliaserWriter
+
+	// RemoveAlias removes [alias] from the ID it is mapped to. The other
+	// aliases of that ID keep their order; if [alias] was the primary
+	// alias, the next one becomes primary.
+	RemoveAlias(alias string) error
+
+	// SetPrimaryAlias makes [alias], which must already be an alias of
+	// [id], the primary alias of [id]. The other aliases keep their order.
+	SetPrimaryAlias(id ID, alias string) error
+
+	// ReplaceAliases makes [aliases], in order, the aliases of [id]. Each
+	// alias must be free or already an alias of [id], and may be listed
+	// once. An empty list removes every alias of [id].
+	ReplaceAliases(id ID, aliases []string) error
+}
+
+// RemoveAlias matches [alias] as Lookup does.
+func (a *aliaser) RemoveAlias(alias string) error {
+	defer a.events.deliver()
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	key := a.key(alias)
+	id, ok := a.dealias[key]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNoIDWithAlias, alias)
+	}
+	spelling := a.spelling(id, key)
+	aliases := slices.DeleteFunc(slices.Clone(a.aliases[id]), func(s string) bool {
+		return s == spelling
+	})
+	return a.setAliases(id, aliases, false)
+}
+
+// SetPrimaryAlias matches [alias] as Lookup does.
+func (a *aliaser) SetPrimaryAlias(id ID, alias string) error {
+	defer a.events.deliver()
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	key := a.key(alias)
+	if owner, ok := a.dealias[key]; !ok || owner != id {
+		return fmt.Errorf("%w: %s is not an alias of %s", ErrAliasNotOfID, alias, id)
+	}
+	spelling := a.spelling(id, key)
+	current := a.aliases[id]
+	if current[0] == spelling {
+		return nil
+	}
+	aliases := make([]string, 1, len(current))
+	aliases[0] = spelling
+	for _, s := range current {
+		if s != spelling {
+			aliases = append(aliases, s)
+		}
+	}
+	return a.setAliases(id, aliases, true)
+}
+
+// ReplaceAliases checks new aliases, and new spellings of existing ones,
+// against the aliaser's policy.
+func (a *aliaser) ReplaceAliases(id ID, aliases []string) error {
+	defer a.events.deliver()
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	current := a.aliases[id]
+	if slices.Equal(current, aliases) {
+		return nil
+	}
+	listed := make(map[string]string, len(aliases))
+	for _, alias := range aliases {
+		key := a.key(alias)
+		if prev, ok := listed[key]; ok {
+			return fmt.Errorf("%w: %s and %s are both listed", ErrAliasAlreadyMapped, prev, alias)
+		}
+		listed[key] = alias
+		if owner, ok := a.dealias[key]; ok && owner != id {
+			return a.clashError(owner, key, alias)
+		}
+		if slices.Contains(current, alias) {
+			continue
+		}
+		if err := a.checkPolicy(id, alias); err != nil {
+			return err
+		}
+	}
+	return a.setAliases(id, slices.Clone(aliases), true)
+}
+
+// setAliases makes [aliases], which the caller has checked, the aliases of
+// [id], persisting the change first. The aliaser takes ownership of
+// [aliases]; the slice of [id] is replaced rather than modified, since
+// Aliases hands it out. If [reorder] is false, the aliases kept must be in
+// their current relative order, so only removals and additions are
+// persisted; otherwise every alias is rewritten with a fresh sequence
+// number to record the new order.
+func (a *aliaser) setAliases(id ID, aliases []string, reorder bool) error {
+	current := a.aliases[id]
+	var removed []string
+	for _, alias := range current {
+		if !slices.Contains(aliases, alias) {
+			removed = append(removed, alias)
+		}
+	}
+
+	if a.store != nil {
+		var (
+			batch = make([]AliasStoreWrite, 0, len(removed)+len(aliases))
+			seq   = a.nextSeq
+		)
+		for _, alias := range removed {
+			batch = append(batch, AliasStoreWrite{Key: []byte(alias)})
+		}
+		for _, alias := range aliases {
+			if !reorder && slices.Contains(current, alias) {
+				continue
+			}
+			batch = append(batch, AliasStoreWrite{
+				Key:   []byte(alias),
+				Value: aliasRecord(seq, id),
+			})
+			seq++
+		}
+		if err := a.store.Write(batch); err != nil {
+			return fmt.Errorf("couldn't persist aliases of %s: %w", id, err)
+		}
+		a.nextSeq = seq
+	}
+
+	// Removals go first, so that a new spelling of a removed alias, which
+	// has the same key under normalization, is not deleted.
+	for _, alias := range removed {
+		delete(a.dealias, a.key(alias))
+		a.events.queue(AliasRemoved, id, alias)
+	}
+	for _, alias := range aliases {
+		if !slices.Contains(current, alias) {
+			a.dealias[a.key(alias)] = id
+			a.events.queue(AliasAdded, id, alias)
+		}
+	}
+	if len(aliases) == 0 {
+		delete(a.aliases, id)
+		return nil
+	}
+	a.aliases[id] = aliases
+	if len(current) > 0 && current[0] != aliases[0] && slices.Contains(current, aliases[0]) {
+		a.events.queue(AliasPrimarySet, id, aliases[0])
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/luxfi/ids"
+)
+
+func TestAliaserEditPersists(t *testing.T) {
+	require := require.New(t)
+
+	store, err := NewFileAliasStore(filepath.Join(t.TempDir(), "aliases.json"))
+	require.NoError(err)
+	a, err := NewPersistentAliaser(store)
+	require.NoError(err)
+
+	id1 := ID{'B', 'r', 'u', 'c', 'e'}
+	id2 := ID{'D', 'i', 'c', 'k'}
+	require.NoError(a.Alias(id1, "Batman"))
+	require.NoError(a.Alias(id1, "Dark Knight"))
+	require.NoError(a.Alias(id1, "Caped Crusader"))
+	require.NoError(a.Alias(id2, "Robin"))
+
+	require.NoError(a.SetPrimaryAlias(id1, "Caped Crusader"))
+	require.NoError(a.RemoveAlias("Batman"))
+	require.NoError(a.Alias(id1, "Batman"))
+	require.NoError(a.ReplaceAliases(id2, []string{"Nightwing", "Robin"}))
+
+	reloaded, err := NewPersistentAliaser(store)
+	require.NoError(err)
+	for id, expected := range map[ID][]string{
+		id1: {"Caped Crusader", "Dark Knight", "Batman"},
+		id2: {"Nightwing", "Robin"},
+	} {
+		aliases, err := reloaded.Aliases(id)
+		require.NoError(err)
+		require.Equal(expected, aliases)
+	}
+
+	// Aliases given after a reload still come last.
+	require.NoError(reloaded.Alias(id2, "Red Hood"))
+	reloaded, err = NewPersistentAliaser(store)
+	require.NoError(err)
+	aliases, err := reloaded.Aliases(id2)
+	require.NoError(err)
+	require.Equal([]string{"Nightwing", "Robin", "Red Hood"}, aliases)
+}
+
+func TestAliaserEditStoreFailure(t *testing.T) {
+	require := require.New(t)
+
+	store := &failingAliasStore{AliasStore: NewMemoryAliasStore()}
+	a, err := NewPersistentAliaser(store)
+	require.NoError(err)
+
+	id := ID{'B', 'a', 'n', 'e'}
+	require.NoError(a.Alias(id, "Bane"))
+	require.NoError(a.Alias(id, "Venom"))
+
+	store.fail = true
+	require.ErrorIs(a.RemoveAlias("Bane"), errStoreFailed)
+	require.ErrorIs(a.SetPrimaryAlias(id, "Venom"), errStoreFailed)
+	require.ErrorIs(a.ReplaceAliases(id, []string{"Venom", "Luchador"}), errStoreFailed)
+	require.NoError(a.Err(), "edits report their own errors")
+
+	aliases, err := a.Aliases(id)
+	require.NoError(err)
+	require.Equal([]string{"Bane", "Venom"}, aliases, "a failed edit must not be applied")
+	_, err = a.Lookup("Luchador")
+	require.ErrorIs(err, ErrNoIDWithAlias)
+
+	store.fail = false
+	reloaded, err := NewPersistentAliaser(store)
+	require.NoError(err)
+	aliases, err = reloaded.Aliases(id)
+	require.NoError(err)
+	require.Equal([]string{"Bane", "Venom"}, aliases)
+}
+
+func TestAliaserEditEvents(t *testing.T) {
+	require := require.New(t)
+
+	a, s := newSubscriber(t)
+	e := a.(AliaserEditor)
+	id := ID{'B', 'r', 'u', 'c', 'e'}
+	require.NoError(a.Alias(id, "Batman"))
+	require.NoError(a.Alias(id, "Dark Knight"))
+	require.NoError(a.Alias(id, "Caped Crusader"))
+
+	var events []AliasEvent
+	defer s.Subscribe(func(e AliasEvent) {
+		events = append(events, e)
+	})()
+
+	require.NoError(e.SetPrimaryAlias(id, "Dark Knight"))
+	require.NoError(e.SetPrimaryAlias(id, "Dark Knight"))
+	require.NoError(e.RemoveAlias("Dark Knight"))
+	require.NoError(e.RemoveAlias("Caped Crusader"))
+	require.NoError(e.ReplaceAliases(id, []string{"Bruce", "Batman"}))
+	require.NoError(e.ReplaceAliases(id, []string{"Bruce", "Batman"}))
+
+	require.Equal([]AliasEvent{
+		{Seq: 4, Type: AliasPrimarySet, ID: id, Alias: "Dark Knight"},
+		{Seq: 5, Type: AliasRemoved, ID: id, Alias: "Dark Knight"},
+		{Seq: 6, Type: AliasPrimarySet, ID: id, Alias: "Batman"},
+		{Seq: 7, Type: AliasRemoved, ID: id, Alias: "Caped Crusader"},
+		{Seq: 8, Type: AliasAdded, ID: id, Alias: "Bruce"},
+	}, events)
+}
+
+func TestAliaserEditKeepsReturnedAliases(t *testing.T) {
+	require := require.New(t)
+
+	a := NewAliaser()
+	e := a.(AliaserEditor)
+	id := ID{'B', 'r', 'u', 'c', 'e'}
+	require.NoError(a.Alias(id, "Batman"))
+	require.NoError(a.Alias(id, "Dark Knight"))
+
+	aliases, err := a.Aliases(id)
+	require.NoError(err)
+	require.NoError(e.SetPrimaryAlias(id, "Dark Knight"))
+	require.NoError(e.RemoveAlias("Batman"))
+	require.Equal([]string{"Batman", "Dark Knight"}, aliases)
+}
+
+func TestAliaserEditNormalized(t *testing.T) {
+	require := require.New(t)
+
+	a := NewAliaser(WithNormalizedLookup())
+	e := a.(AliaserEditor)
+	require.NoError(a.Alias(XChainID, "X-Chain"))
+	require.NoError(a.Alias(XChainID, "Swap"))
+	require.NoError(a.Alias(CChainID, "EVM"))
+
+	// Aliases are matched as Lookup matches them, and keep their spelling.
+	require.NoError(e.SetPrimaryAlias(XChainID, "SWAP"))
+	require.Equal("Swap", a.PrimaryAliasOrDefault(XChainID))
+	require.NoError(e.RemoveAlias("x-chain"))
+	_, err := a.Lookup("X-Chain")
+	require.ErrorIs(err, ErrNoIDWithAlias)
+
+	// A new spelling of an alias of the ID replaces the old one.
+	require.NoError(e.ReplaceAliases(XChainID, []string{"swap", "X"}))
+	aliases, err := a.Aliases(XChainID)
+	require.NoError(err)
+	require.Equal([]string{"swap", "X"}, aliases)
+
+	// Clashes are found under normalization.
+	require.ErrorIs(e.ReplaceAliases(XChainID, []string{"x", "X"}), ErrAliasAlreadyMapped)
+	require.ErrorIs(e.ReplaceAliases(XChainID, []string{"evm"}), ErrAliasAlreadyMapped)
+	require.ErrorIs(e.SetPrimaryAlias(XChainID, "ｅｖｍ"), ErrAliasNotOfID)
+	aliases, err = a.Aliases(XChainID)
+	require.NoError(err)
+	require.Equal([]string{"swap", "X"}, aliases)
+}
+
+func TestAliaserEditPolicy(t *testing.T) {
+	require := require.New(t)
+
+	a := NewAliaserWithPolicy(DefaultAliasPolicy)
+	e := a.(AliaserEditor)
+	id := ID{'B', 'r', 'u', 'c', 'e'}
+	require.NoError(a.Alias(id, "Batman"))
+
+	err := e.ReplaceAliases(id, []string{"Batman", "P"})
+	require.ErrorIs(err, ErrAliasShadowsNativeChain)
+	aliases, err := a.Aliases(id)
+	require.NoError(err)
+	require.Equal([]string{"Batman"}, aliases)
+}
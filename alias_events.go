@@ -0,0 +1,229 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Alias change events.
+//
+// Every mutation of an aliaser queues one event per alias added or removed,
+// and one when an ID's primary alias becomes an alias it already had,
+// numbered with a per-aliaser sequence number, while the aliaser lock is
+// held; that fixes the order. The events are delivered after the lock is
+// released, by whichever mutating goroutine finds no delivery in progress,
+// so subscribers may call back into the aliaser. At most one goroutine
+// delivers at a time, so every subscriber sees events in sequence order.
+
+var _ AliasSubscriber = (*aliaser)(nil)
+
+// AliasEventType says what happened to an alias.
+type AliasEventType uint8
+
+const (
+	AliasAdded AliasEventType = iota + 1
+	AliasRemoved
+	// AliasPrimarySet reports that an alias the ID already had became its
+	// primary alias, through AliaserEditor. An alias that is primary
+	// because it was added to an ID without aliases is only reported as
+	// added.
+	AliasPrimarySet
+)
+
+// String returns "added", "removed" or "primary-set".
+func (t AliasEventType) String() string {
+	switch t {
+	case AliasAdded:
+		return "added"
+	case AliasRemoved:
+		return "removed"
+	case AliasPrimarySet:
+		return "primary-set"
+	default:
+		return fmt.Sprintf("alias-event(%d)", uint8(t))
+	}
+}
+
+// AliasEvent reports that [Alias] was added to or removed from [ID], or
+// became its primary alias. Seq starts at 1 and increases by one with every
+// event of an aliaser, whether or not anyone is subscribed.
+type AliasEvent struct {
+	Seq   uint64
+	Type  AliasEventType
+	ID    ID
+	Alias string
+}
+
+// AliasSubscriber is implemented by the aliasers of this package. A
+// subscriber only sees events for mutations made after it subscribed.
+type AliasSubscriber interface {
+	// Subscribe calls [f] with every later event, in order, until the
+	// returned function is called. [f] runs without the aliaser lock held
+	// and may call back into the aliaser; it should not block, since events
+	// are delivered to all subscribers in turn. An event already being
+	// delivered when the subscription is cancelled may still reach [f].
+	Subscribe(f func(AliasEvent)) (unsubscribe func())
+
+	// Watch returns a channel of later events with capacity [buffer].
+	// Delivery blocks while the channel is full, so the channel must be
+	// drained promptly. The returned function cancels the watch and closes
+	// the channel.
+	Watch(buffer int) (<-chan AliasEvent, func())
+}
+
+type aliasSubscription struct {
+	f         func(AliasEvent)
+	cancelled atomic.Bool
+}
+
+// aliasEventQueue is the subscriber list and pending events of one aliaser.
+type aliasEventQueue struct {
+	lock sync.Mutex
+	seq  uint64
+	// subscribers is copy-on-write, so pending events can share it.
+	subscribers []*aliasSubscription
+	pending     []pendingAliasEvent
+	delivering  bool
+}
+
+// pendingAliasEvent is an event together with the subscribers at the time
+// it was queued.
+type pendingAliasEvent struct {
+	event       AliasEvent
+	subscribers []*aliasSubscription
+}
+
+// queue numbers an event and, if anyone is subscribed, queues it for
+// delivery. The caller must hold the aliaser lock and call deliver after
+// releasing it.
+func (q *aliasEventQueue) queue(t AliasEventType, id ID, alias string) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.seq++
+	if len(q.subscribers) == 0 {
+		return
+	}
+	q.pending = append(q.pending, pendingAliasEvent{
+		event: AliasEvent{
+			Seq:   q.seq,
+			Type:  t,
+			ID:    id,
+			Alias: alias,
+		},
+		subscribers: q.subscribers,
+	})
+}
+
+// deliver delivers the pending events, unless another goroutine already
+// is, in which case that goroutine delivers them.
+func (q *aliasEventQueue) deliver() {
+	q.lock.Lock()
+	if q.delivering {
+		q.lock.Unlock()
+		return
+	}
+	q.delivering = true
+
+	// If a subscriber panics, let the next mutation resume delivery.
+	finished := false
+	defer func() {
+		if !finished {
+			q.lock.Lock()
+			q.delivering = false
+			q.lock.Unlock()
+		}
+	}()
+
+	for {
+		pending := q.pending
+		q.pending = nil
+		if len(pending) == 0 {
+			// Cleared in the same critical section that saw the queue
+			// empty, so no queued event is left without a deliverer.
+			q.delivering = false
+			q.lock.Unlock()
+			finished = true
+			return
+		}
+		q.lock.Unlock()
+
+		for _, p := range pending {
+			for _, s := range p.subscribers {
+				if !s.cancelled.Load() {
+					s.f(p.event)
+				}
+			}
+		}
+		q.lock.Lock()
+	}
+}
+
+func (q *aliasEventQueue) subscribe(f func(AliasEvent)) func() {
+	s := &aliasSubscription{f: f}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.subscribers = append(slices.Clip(q.subscribers), s)
+	return func() {
+		s.cancelled.Store(true)
+
+		q.lock.Lock()
+		defer q.lock.Unlock()
+
+		if i := slices.Index(q.subscribers, s); i >= 0 {
+			q.subscribers = slices.Delete(slices.Clone(q.subscribers), i, i+1)
+		}
+	}
+}
+
+func (q *aliasEventQueue) watch(buffer int) (<-chan AliasEvent, func()) {
+	var (
+		ch   = make(chan AliasEvent, buffer)
+		done = make(chan struct{})
+		// sendLock is held while sending, so the channel is never closed
+		// under a send.
+		sendLock sync.Mutex
+		closed   bool
+	)
+	unsubscribe := q.subscribe(func(e AliasEvent) {
+		sendLock.Lock()
+		defer sendLock.Unlock()
+
+		if closed {
+			return
+		}
+		select {
+		case ch <- e:
+		case <-done:
+		}
+	})
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			close(done)
+			unsubscribe()
+
+			sendLock.Lock()
+			defer sendLock.Unlock()
+
+			closed = true
+			close(ch)
+		})
+	}
+}
+
+func (a *aliaser) Subscribe(f func(AliasEvent)) func() {
+	return a.events.subscribe(f)
+}
+
+func (a *aliaser) Watch(buffer int) (<-chan AliasEvent, func()) {
+	return a.events.watch(buffer)
+}
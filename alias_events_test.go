@@ -0,0 +1,176 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	. "github.com/luxfi/ids"
+)
+
+func newSubscriber(t *testing.T) (Aliaser, AliasSubscriber) {
+	a := NewAliaser()
+	s, ok := a.(AliasSubscriber)
+	require.True(t, ok)
+	return a, s
+}
+
+func TestAliaserSubscribe(t *testing.T) {
+	require := require.New(t)
+
+	a, s := newSubscriber(t)
+	id := ID{'B', 'r', 'u', 'c', 'e'}
+
+	// Mutations before subscribing are not reported but are numbered.
+	require.NoError(a.Alias(id, "Bruce"))
+
+	var events []AliasEvent
+	unsubscribe := s.Subscribe(func(e AliasEvent) {
+		events = append(events, e)
+	})
+	require.NoError(a.Alias(id, "Batman"))
+	require.NoError(a.Alias(id, "Dark Knight"))
+	require.ErrorIs(a.Alias(id, "Batman"), ErrAliasAlreadyMapped)
+	a.RemoveAliases(id)
+
+	require.Equal([]AliasEvent{
+		{Seq: 2, Type: AliasAdded, ID: id, Alias: "Batman"},
+		{Seq: 3, Type: AliasAdded, ID: id, Alias: "Dark Knight"},
+		{Seq: 4, Type: AliasRemoved, ID: id, Alias: "Bruce"},
+		{Seq: 5, Type: AliasRemoved, ID: id, Alias: "Batman"},
+		{Seq: 6, Type: AliasRemoved, ID: id, Alias: "Dark Knight"},
+	}, events)
+
+	unsubscribe()
+	unsubscribe()
+	require.NoError(a.Alias(id, "Batman"))
+	require.Len(events, 5)
+}
+
+func TestAliaserSubscribeReentrant(t *testing.T) {
+	require := require.New(t)
+
+	a, s := newSubscriber(t)
+	id := ID{'J', 'o', 'k', 'e', 'r'}
+
+	// A subscriber may read and mutate the aliaser. Its own mutation is
+	// delivered as a later event instead of deadlocking or interleaving.
+	var (
+		events  []AliasEvent
+		primary string
+	)
+	s.Subscribe(func(e AliasEvent) {
+		events = append(events, e)
+		if e.Alias == "Joker" {
+			primary = a.PrimaryAliasOrDefault(id)
+			require.NoError(a.Alias(id, "Clown Prince"))
+		}
+	})
+	require.NoError(a.Alias(id, "Joker"))
+
+	require.Equal("Joker", primary)
+	require.Equal([]AliasEvent{
+		{Seq: 1, Type: AliasAdded, ID: id, Alias: "Joker"},
+		{Seq: 2, Type: AliasAdded, ID: id, Alias: "Clown Prince"},
+	}, events)
+}
+
+func TestAliaserSubscribeConcurrentOrder(t *testing.T) {
+	require := require.New(t)
+
+	a, s := newSubscriber(t)
+
+	var (
+		lock    sync.Mutex
+		lastSeq uint64
+		count   int
+	)
+	s.Subscribe(func(e AliasEvent) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if e.Seq != lastSeq+1 {
+			panic("events delivered out of order")
+		}
+		lastSeq = e.Seq
+		count++
+	})
+
+	const (
+		numGoroutines = 8
+		numAliases    = 50
+	)
+	var wg sync.WaitGroup
+	for g := range numGoroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id := ID{byte(g)}
+			for i := range numAliases {
+				if err := a.Alias(id, string([]byte{byte(g), byte(i)})); err != nil {
+					panic(err)
+				}
+			}
+			a.RemoveAliases(id)
+		}()
+	}
+	wg.Wait()
+
+	lock.Lock()
+	defer lock.Unlock()
+	require.Equal(2*numGoroutines*numAliases, count)
+}
+
+func TestAliaserWatch(t *testing.T) {
+	require := require.New(t)
+
+	a, s := newSubscriber(t)
+	id := ID{'S', 'e', 'l', 'i', 'n', 'a'}
+
+	events, cancel := s.Watch(2)
+	require.NoError(a.Alias(id, "Catwoman"))
+	a.RemoveAliases(id)
+	require.Equal(AliasEvent{Seq: 1, Type: AliasAdded, ID: id, Alias: "Catwoman"}, <-events)
+	require.Equal(AliasEvent{Seq: 2, Type: AliasRemoved, ID: id, Alias: "Catwoman"}, <-events)
+
+	cancel()
+	cancel()
+	_, ok := <-events
+	require.False(ok, "cancel must close the channel")
+	require.NoError(a.Alias(id, "Catwoman"))
+}
+
+func TestAliaserWatchCancelUnblocksDelivery(t *testing.T) {
+	require := require.New(t)
+
+	a, s := newSubscriber(t)
+	id := ID{'H', 'a', 'r', 'v', 'e', 'y'}
+
+	events, cancel := s.Watch(0)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// Blocks delivering to the unread watch until it is cancelled.
+		_ = a.Alias(id, "Two-Face")
+	}()
+
+	cancel()
+	<-done
+	for range events {
+		require.FailNow("no event may be sent after cancel")
+	}
+	require.Equal("Two-Face", a.PrimaryAliasOrDefault(id))
+}
+
+func TestAliasEventTypeString(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal("added", AliasAdded.String())
+	require.Equal("removed", AliasRemoved.String())
+	require.Equal("primary-set", AliasPrimarySet.String())
+	require.Equal("alias-event(0)", AliasEventType(0).String())
+}
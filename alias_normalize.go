@@ -0,0 +1,48 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// WithNormalizedLookup makes an aliaser match aliases by their
+// NormalizeAlias form, so "X-Chain", "x-chain" and "ｘ-chain" all name the
+// same ID. PrimaryAlias and Aliases still return each alias as it was
+// spelled when added, and Alias refuses an alias whose normalized form is
+// already taken, even under a different spelling.
+func WithNormalizedLookup() AliaserOption {
+	return func(a *aliaser) {
+		a.normalize = NormalizeAlias
+	}
+}
+
+// NormalizeAlias returns the form of [alias] that WithNormalizedLookup
+// matches on: Unicode case folding applied between two NFKC
+// normalizations, which maps compatibility characters (full-width letters,
+// ligatures) to their plain forms and makes the result stable.
+func NormalizeAlias(alias string) string {
+	// A Caser holds state and must not be shared between goroutines.
+	return norm.NFKC.String(cases.Fold().String(norm.NFKC.String(alias)))
+}
+
+// key returns the dealias key of [alias].
+func (a *aliaser) key(alias string) string {
+	if a.normalize == nil {
+		return alias
+	}
+	return a.normalize(alias)
+}
+
+// spelling returns the alias of [id] whose key is [key], as it was spelled
+// when added.
+func (a *aliaser) spelling(id ID, key string) string {
+	for _, alias := range a.aliases[id] {
+		if a.key(alias) == key {
+			return alias
+		}
+	}
+	return key
+}
@@ -0,0 +1,172 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeAlias(t *testing.T) {
+	tests := []struct {
+		alias    string
+		expected string
+	}{
+		{"x-chain", "x-chain"},
+		{"X-Chain", "x-chain"},
+		{"ｘ－ｃｈａｉｎ", "x-chain"}, // full-width
+		{"Straße", "strasse"},
+		{"ﬁnance", "finance"}, // ligature
+		{"Å", "å"},            // decomposed A + ring above
+		{"ΣΊΣΥΦΟΣ", "σίσυφοσ"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.alias, func(t *testing.T) {
+			require := require.New(t)
+
+			normalized := NormalizeAlias(tt.alias)
+			require.Equal(tt.expected, normalized)
+			require.Equal(normalized, NormalizeAlias(normalized))
+		})
+	}
+}
+
+func TestNormalizedLookup(t *testing.T) {
+	require := require.New(t)
+
+	a := NewAliaser(WithNormalizedLookup())
+	require.NoError(a.Alias(XChainID, "X-Chain"))
+	require.NoError(a.Alias(XChainID, "swap"))
+
+	for _, alias := range []string{"X-Chain", "x-chain", "X-CHAIN", "ｘ-chain"} {
+		id, err := a.Lookup(alias)
+		require.NoError(err, alias)
+		require.Equal(XChainID, id, alias)
+	}
+
+	primary, err := a.PrimaryAlias(XChainID)
+	require.NoError(err)
+	require.Equal("X-Chain", primary)
+	aliases, err := a.Aliases(XChainID)
+	require.NoError(err)
+	require.Equal([]string{"X-Chain", "swap"}, aliases)
+
+	// A clash under normalization is refused, for any ID, and names the
+	// existing spelling.
+	err = a.Alias(CChainID, "x-CHAIN")
+	require.ErrorIs(err, ErrAliasAlreadyMapped)
+	require.Contains(err.Error(), "X-Chain")
+	require.ErrorIs(a.Alias(XChainID, "x-chain"), ErrAliasAlreadyMapped)
+
+	// Removal frees the normalized key.
+	a.RemoveAliases(XChainID)
+	_, err = a.Lookup("x-chain")
+	require.ErrorIs(err, ErrNoIDWithAlias)
+	require.NoError(a.Alias(CChainID, "x-chain"))
+}
+
+// TestNormalizedPolicy checks that a policy cannot be bypassed by an alias
+// that only matches a reserved word, native chain name or ID once it is
+// normalized.
+func TestNormalizedPolicy(t *testing.T) {
+	other := ID{'J', 'o', 'k', 'e', 'r'}
+	fullWidthHex := strings.Map(func(r rune) rune {
+		return r - ' ' + '\uff00' // ASCII to full-width
+	}, "0x"+other.Hex())
+	policy := DefaultAliasPolicy
+	policy.MaxLen = 0 // a full-width ID is over the default limit
+	policy.Reserved = []string{"platform", "ADMIN"}
+
+	tests := []struct {
+		name        string
+		alias       string
+		expectedErr error
+	}{
+		{"full-width native chain", "Ｐ", ErrAliasShadowsNativeChain},
+		{"reserved in another case", "Platform", ErrAliasReserved},
+		{"full-width reserved", "ＰＬＡＴＦＯＲＭ", ErrAliasReserved},
+		{"reserved word in another case", "admin", ErrAliasReserved},
+		{"full-width hex ID", fullWidthHex, ErrAliasIsForeignID},
+		{"allowed", "Bruce", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			a := NewAliaser(WithNormalizedLookup(), WithAliasPolicy(policy))
+			require.ErrorIs(a.Alias(ID{7}, tt.alias), tt.expectedErr)
+			if tt.expectedErr != nil {
+				_, err := a.Lookup(NormalizeAlias(tt.alias))
+				require.ErrorIs(err, ErrNoIDWithAlias)
+			}
+		})
+	}
+}
+
+func TestNormalizedLookupDisabled(t *testing.T) {
+	require := require.New(t)
+
+	a := NewAliaser()
+	require.NoError(a.Alias(XChainID, "X-Chain"))
+	require.NoError(a.Alias(CChainID, "x-chain"))
+	_, err := a.Lookup("X-CHAIN")
+	require.ErrorIs(err, ErrNoIDWithAlias)
+}
+
+func TestNormalizedLoadAliases(t *testing.T) {
+	require := require.New(t)
+
+	a := NewAliaser(WithNormalizedLookup())
+	require.NoError(a.Alias(XChainID, "Swap"))
+
+	err := LoadAliases(strings.NewReader(`{"C": ["EVM", "evm", "swap"]}`), a)
+	var conflictErr *AliasConflictError
+	require.ErrorAs(err, &conflictErr)
+	require.Len(conflictErr.Conflicts, 2)
+	require.Equal("evm", conflictErr.Conflicts[0].Alias)
+	require.ErrorIs(conflictErr.Conflicts[0].Err, ErrAliasAlreadyMapped)
+	require.Equal("swap", conflictErr.Conflicts[1].Alias)
+	require.ErrorIs(conflictErr.Conflicts[1].Err, ErrAliasAlreadyMapped)
+	_, err = a.Lookup("evm")
+	require.ErrorIs(err, ErrNoIDWithAlias)
+
+	// The spelling already in place is skipped; a new spelling of it for
+	// the same ID still clashes.
+	require.NoError(LoadAliases(strings.NewReader(`{"X": ["Swap"], "C": ["EVM"]}`), a))
+	err = LoadAliases(strings.NewReader(`{"X": ["SWAP"]}`), a)
+	require.ErrorIs(err, ErrAliasAlreadyMapped)
+
+	id, err := a.Lookup("evm")
+	require.NoError(err)
+	require.Equal(CChainID, id)
+}
+
+func TestNormalizedPersistentAliaser(t *testing.T) {
+	require := require.New(t)
+
+	store := NewMemoryAliasStore()
+	a, err := NewPersistentAliaser(store, WithNormalizedLookup())
+	require.NoError(err)
+	require.NoError(a.Alias(XChainID, "X-Chain"))
+	require.NoError(a.Alias(XChainID, "Swap"))
+
+	reloaded, err := NewPersistentAliaser(store, WithNormalizedLookup())
+	require.NoError(err)
+	id, err := reloaded.Lookup("SWAP")
+	require.NoError(err)
+	require.Equal(XChainID, id)
+	aliases, err := reloaded.Aliases(XChainID)
+	require.NoError(err)
+	require.Equal([]string{"X-Chain", "Swap"}, aliases)
+
+	// A store written without normalization may hold aliases that clash
+	// under it.
+	plain, err := NewPersistentAliaser(store)
+	require.NoError(err)
+	require.NoError(plain.Alias(CChainID, "swap"))
+	_, err = NewPersistentAliaser(store, WithNormalizedLookup())
+	require.ErrorIs(err, ErrAliasAlreadyMapped)
+}
@@ -0,0 +1,177 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	// ErrAliasMalformed — the alias is empty, is not valid UTF-8, has
+	// leading or trailing whitespace, or contains a control character.
+	ErrAliasMalformed = errors.New("malformed alias")
+
+	// ErrAliasTooLong — the alias is longer than AliasPolicy.MaxLen bytes.
+	ErrAliasTooLong = errors.New("alias too long")
+
+	// ErrAliasCharset — the alias has a character outside
+	// AliasPolicy.Charset.
+	ErrAliasCharset = errors.New("alias has a disallowed character")
+
+	// ErrAliasReserved — the alias is listed in AliasPolicy.Reserved.
+	ErrAliasReserved = errors.New("alias is reserved")
+
+	// ErrAliasShadowsNativeChain — the alias is a native chain name, such as
+	// "P", given to an ID other than that chain's.
+	ErrAliasShadowsNativeChain = errors.New("alias shadows a native chain")
+
+	// ErrAliasIsForeignID — the alias parses as an ID other than the one it
+	// is given to.
+	ErrAliasIsForeignID = errors.New("alias parses as a different ID")
+)
+
+// DefaultAliasPolicy is a policy suitable for chain aliases read from
+// operator configuration.
+var DefaultAliasPolicy = AliasPolicy{
+	MaxLen:                     128,
+	ForbidNativeChainShadowing: true,
+	ForbidForeignIDs:           true,
+}
+
+// AliasPolicy restricts the aliases an Aliaser accepts. Whatever its
+// settings, a policy refuses aliases that are empty, are not valid UTF-8,
+// have leading or trailing whitespace, or contain control characters.
+type AliasPolicy struct {
+	// MaxLen is the maximum length of an alias in bytes. Zero means no
+	// limit.
+	MaxLen int
+
+	// Charset, if non-empty, lists every character an alias may contain.
+	Charset string
+
+	// Reserved lists aliases that may not be given to any ID. Matching is
+	// exact, or by NormalizeAlias form for an aliaser with
+	// WithNormalizedLookup.
+	Reserved []string
+
+	// ForbidNativeChainShadowing refuses native chain names, in any form
+	// NativeChainFromString accepts, as aliases of any ID but that chain.
+	ForbidNativeChainShadowing bool
+
+	// ForbidForeignIDs refuses aliases that encode an ID, in CB58 or hex,
+	// unless they encode the ID being aliased. Native chain names are not
+	// IDs here; see ForbidNativeChainShadowing.
+	ForbidForeignIDs bool
+}
+
+// Check returns nil if this policy allows [alias] to be given to [id], and
+// an error wrapping one of the ErrAlias* sentinels otherwise.
+func (p AliasPolicy) Check(id ID, alias string) error {
+	return p.check(id, alias, nil)
+}
+
+// check is Check for an aliaser that looks aliases up by their [normalize]
+// form, if non-nil. Reserved words, native chain names and IDs are then
+// also matched against that form, since it is what Lookup will see.
+func (p AliasPolicy) check(id ID, alias string, normalize func(string) string) error {
+	if err := checkAliasWellFormed(alias); err != nil {
+		return err
+	}
+	if p.MaxLen > 0 && len(alias) > p.MaxLen {
+		return fmt.Errorf("%w: %q is %d bytes, max %d", ErrAliasTooLong, alias, len(alias), p.MaxLen)
+	}
+	if p.Charset != "" {
+		for _, r := range alias {
+			if !strings.ContainsRune(p.Charset, r) {
+				return fmt.Errorf("%w: %q contains %q", ErrAliasCharset, alias, r)
+			}
+		}
+	}
+	forms := []string{alias}
+	if normalize != nil {
+		if key := normalize(alias); key != alias {
+			forms = append(forms, key)
+		}
+	}
+	for _, form := range forms {
+		if err := p.checkForm(id, alias, form, normalize); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkForm runs the checks of check that match [form], which is [alias]
+// or its normalized form, against reserved words, native chain names and
+// IDs.
+func (p AliasPolicy) checkForm(id ID, alias, form string, normalize func(string) string) error {
+	reserved := slices.ContainsFunc(p.Reserved, func(r string) bool {
+		return r == form || normalize != nil && normalize(r) == form
+	})
+	if reserved {
+		return fmt.Errorf("%w: %q", ErrAliasReserved, alias)
+	}
+	if p.ForbidNativeChainShadowing {
+		if native, ok := NativeChainFromString(form); ok && native != id {
+			return fmt.Errorf("%w: %q is %s, not %s", ErrAliasShadowsNativeChain, alias, native, id)
+		}
+	}
+	if p.ForbidForeignIDs {
+		if parsed, ok := decodeAliasID(form); ok && parsed != id {
+			return fmt.Errorf("%w: %q is %s, not %s", ErrAliasIsForeignID, alias, parsed, id)
+		}
+	}
+	return nil
+}
+
+// decodeAliasID returns the ID that [s] encodes in CB58 or hex. Unlike
+// Parse, it does not resolve native chain names; those are left to
+// ForbidNativeChainShadowing.
+func decodeAliasID(s string) (ID, bool) {
+	var id ID
+	if decodeCB58(id[:], s) {
+		return id, true
+	}
+	id, err := FromHex(s)
+	return id, err == nil
+}
+
+func checkAliasWellFormed(alias string) error {
+	switch {
+	case alias == "":
+		return fmt.Errorf("%w: empty", ErrAliasMalformed)
+	case !utf8.ValidString(alias):
+		return fmt.Errorf("%w: %q is not valid UTF-8", ErrAliasMalformed, alias)
+	}
+	first, _ := utf8.DecodeRuneInString(alias)
+	last, _ := utf8.DecodeLastRuneInString(alias)
+	if unicode.IsSpace(first) || unicode.IsSpace(last) {
+		return fmt.Errorf("%w: %q has leading or trailing whitespace", ErrAliasMalformed, alias)
+	}
+	if i := strings.IndexFunc(alias, unicode.IsControl); i >= 0 {
+		return fmt.Errorf("%w: %q has a control character at byte %d", ErrAliasMalformed, alias, i)
+	}
+	return nil
+}
+
+// WithAliasPolicy makes an aliaser refuse, with the error of
+// AliasPolicy.Check, every alias [policy] does not allow. A persistent
+// aliaser also checks the aliases it loads from its store.
+func WithAliasPolicy(policy AliasPolicy) AliaserOption {
+	return func(a *aliaser) {
+		a.policy = &policy
+	}
+}
+
+// NewAliaserWithPolicy returns an empty in-memory Aliaser with
+// WithAliasPolicy([policy]) applied after [opts]. It also implements
+// AliaserEditor and AliasSubscriber.
+func NewAliaserWithPolicy(policy AliasPolicy, opts ...AliaserOption) Aliaser {
+	return newAliaser(append(opts[:len(opts):len(opts)], WithAliasPolicy(policy))...)
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/ids/idstest"
+
+	. "github.com/luxfi/ids"
+)
+
+func TestAliaserWithPolicy(t *testing.T) {
+	idstest.RunAllAlias(t, func() (AliaserReader, AliaserWriter) {
+		a := NewAliaserWithPolicy(DefaultAliasPolicy)
+		return a, a
+	})
+	idstest.RunAllAliasEditor(t, func() (AliaserReader, AliaserEditor) {
+		a := NewAliaserWithPolicy(DefaultAliasPolicy)
+		return a, a.(AliaserEditor)
+	})
+}
+
+func TestWithAliasPolicy(t *testing.T) {
+	require := require.New(t)
+
+	a := NewAliaser(WithAliasPolicy(AliasPolicy{Reserved: []string{"admin"}}))
+	require.ErrorIs(a.Alias(ID{1}, "admin"), ErrAliasReserved)
+
+	// The policy argument of NewAliaserWithPolicy wins over an option.
+	a = NewAliaserWithPolicy(AliasPolicy{}, WithAliasPolicy(AliasPolicy{Reserved: []string{"admin"}}))
+	require.NoError(a.Alias(ID{1}, "admin"))
+}
+
+func TestAliasPolicyCheck(t *testing.T) {
+	id := ID{'B', 'r', 'u', 'c', 'e'}
+	other := ID{'J', 'o', 'k', 'e', 'r'}
+	policy := AliasPolicy{
+		MaxLen:                     16,
+		Charset:                    "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789- ",
+		Reserved:                   []string{"null", "default"},
+		ForbidNativeChainShadowing: true,
+		ForbidForeignIDs:           true,
+	}
+	tests := []struct {
+		name        string
+		policy      AliasPolicy
+		id          ID
+		alias       string
+		expectedErr error
+	}{
+		{"valid", policy, id, "Dark Knight", nil},
+		{"empty", AliasPolicy{}, id, "", ErrAliasMalformed},
+		{"leading space", AliasPolicy{}, id, " Batman", ErrAliasMalformed},
+		{"trailing newline", AliasPolicy{}, id, "Batman\n", ErrAliasMalformed},
+		{"control character", AliasPolicy{}, id, "Bat\x00man", ErrAliasMalformed},
+		{"invalid UTF-8", AliasPolicy{}, id, "Bat\xffman", ErrAliasMalformed},
+		{"too long", policy, id, strings.Repeat("a", 17), ErrAliasTooLong},
+		{"max length", policy, id, strings.Repeat("a", 16), nil},
+		{"charset", policy, id, "bat_man", ErrAliasCharset},
+		{"non-ASCII outside charset", policy, id, "Bätman", ErrAliasCharset},
+		{"reserved", policy, id, "null", ErrAliasReserved},
+		{"native letter", policy, id, "P", ErrAliasShadowsNativeChain},
+		{"native letter lower case", policy, id, "p", ErrAliasShadowsNativeChain},
+		{"native full string", AliasPolicy{ForbidNativeChainShadowing: true}, id, PChainIDStr, ErrAliasShadowsNativeChain},
+		{"native letter for its own chain", policy, PChainID, "P", nil},
+		{"shadowing allowed", AliasPolicy{}, id, "P", nil},
+		{"foreign CB58 ID", AliasPolicy{ForbidForeignIDs: true}, id, other.String(), ErrAliasIsForeignID},
+		{"foreign hex ID", AliasPolicy{ForbidForeignIDs: true}, id, "0x" + other.Hex(), ErrAliasIsForeignID},
+		{"own CB58 ID", AliasPolicy{ForbidForeignIDs: true}, id, id.String(), nil},
+		{"native letter is not a foreign ID", AliasPolicy{ForbidForeignIDs: true}, id, "P", nil},
+		{"native full string is not a foreign ID", AliasPolicy{ForbidForeignIDs: true}, id, PChainIDStr, nil},
+		{"foreign IDs allowed", AliasPolicy{}, id, other.String(), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.id, tt.alias)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestAliaserWithPolicyRejects(t *testing.T) {
+	require := require.New(t)
+
+	a := NewAliaserWithPolicy(DefaultAliasPolicy)
+	id := ID{'B', 'r', 'u', 'c', 'e'}
+
+	require.ErrorIs(a.Alias(id, "X"), ErrAliasShadowsNativeChain)
+	require.ErrorIs(a.Alias(id, ""), ErrAliasMalformed)
+	_, err := a.Lookup("X")
+	require.ErrorIs(err, ErrNoIDWithAlias)
+
+	require.NoError(a.Alias(XChainID, "X"))
+	require.NoError(a.Alias(id, id.String()))
+
+	// LoadAliases reports policy rejections as conflicts and applies
+	// nothing.
+	err = LoadAliases(strings.NewReader(`{"C": ["evm", "P", " spaced"]}`), a)
+	require.ErrorIs(err, ErrAliasConflict)
+	require.ErrorIs(err, ErrAliasShadowsNativeChain)
+	require.ErrorIs(err, ErrAliasMalformed)
+	var conflictErr *AliasConflictError
+	require.ErrorAs(err, &conflictErr)
+	require.Len(conflictErr.Conflicts, 2)
+	_, err = a.Lookup("evm")
+	require.ErrorIs(err, ErrNoIDWithAlias)
+}
@@ -0,0 +1,286 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// Alias persistence.
+//
+// A persistent Aliaser stores one record per alias in an AliasStore:
+//
+//	key   = alias
+//	value = seq || id
+//
+// seq is a big-endian uint64 that increases with every Alias call and id is
+// the raw 32-byte ID. On load, the aliases of each ID are ordered by seq, so
+// the insertion order, and with it PrimaryAlias, survives a restart.
+
+const aliasRecordLen = uint64Len + IDLen
+
+var (
+	_ AliasStore = (*MemoryAliasStore)(nil)
+	_ AliasStore = (*FileAliasStore)(nil)
+
+	errCorruptAliasRecord = errors.New("corrupt alias record")
+)
+
+// AliasStore is the key-value store behind a persistent Aliaser.
+type AliasStore interface {
+	// Iterate calls [f] with every stored key and value, in no particular
+	// order, stopping at the first error. [f] must not retain the slices.
+	Iterate(f func(key, value []byte) error) error
+
+	// Write applies [batch] atomically: either every write is applied or
+	// none is. A write with a nil Value deletes its Key.
+	Write(batch []AliasStoreWrite) error
+}
+
+// AliasStoreWrite is one entry of an AliasStore batch.
+type AliasStoreWrite struct {
+	Key   []byte
+	Value []byte
+}
+
+// PersistentAliaser is an Aliaser whose mutations are written through to an
+// AliasStore.
+type PersistentAliaser interface {
+	Aliaser
+	AliaserEditor
+	AliasSubscriber
+
+	// Err returns the error of the latest RemoveAliases call that failed
+	// to persist, since RemoveAliases has no error return of its own. The
+	// aliases of a failed call are left in place, so memory and store stay
+	// consistent. Callers that need the error of each call should use
+	// ReplaceAliases(id, nil) instead.
+	Err() error
+
+	// ResetErr clears the error returned by Err.
+	ResetErr()
+}
+
+// NewPersistentAliaser loads the aliases held in [store] and returns an
+// Aliaser that persists every later mutation to it. Loading fails if two
+// stored aliases clash under [opts], e.g. after WithNormalizedLookup is
+// enabled on a store whose aliases differ only in case, or if a stored
+// alias is refused by the policy of WithAliasPolicy.
+func NewPersistentAliaser(store AliasStore, opts ...AliaserOption) (PersistentAliaser, error) {
+	type record struct {
+		seq   uint64
+		alias string
+	}
+	records := make(map[ID][]record)
+	a := newAliaser(opts...)
+	a.store = store
+	err := store.Iterate(func(key, value []byte) error {
+		if len(value) != aliasRecordLen {
+			return fmt.Errorf("%w: %q has %d bytes, want %d", errCorruptAliasRecord, key, len(value), aliasRecordLen)
+		}
+		var (
+			alias = string(key)
+			seq   = binary.BigEndian.Uint64(value)
+			id    = ID(value[uint64Len:])
+		)
+		// Stored aliases were accepted by an aliaser that may not have
+		// normalized them.
+		dealiasKey := a.key(alias)
+		if existing, exists := a.dealias[dealiasKey]; exists {
+			return fmt.Errorf("%w: %s of %s clashes with an alias of %s", ErrAliasAlreadyMapped, alias, id, existing)
+		}
+		if err := a.checkPolicy(id, alias); err != nil {
+			return err
+		}
+		a.dealias[dealiasKey] = id
+		records[id] = append(records[id], record{seq: seq, alias: alias})
+		a.nextSeq = max(a.nextSeq, seq+1)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loading aliases: %w", err)
+	}
+
+	for id, rs := range records {
+		slices.SortFunc(rs, func(a, b record) int {
+			return cmp.Or(cmp.Compare(a.seq, b.seq), cmp.Compare(a.alias, b.alias))
+		})
+		aliases := make([]string, len(rs))
+		for i, r := range rs {
+			aliases[i] = r.alias
+		}
+		a.aliases[id] = aliases
+	}
+	return a, nil
+}
+
+func aliasRecord(seq uint64, id ID) []byte {
+	value := make([]byte, aliasRecordLen)
+	binary.BigEndian.PutUint64(value, seq)
+	copy(value[uint64Len:], id[:])
+	return value
+}
+
+// MemoryAliasStore is an in-memory AliasStore. It is safe for concurrent
+// use.
+type MemoryAliasStore struct {
+	lock    sync.RWMutex
+	records map[string][]byte
+}
+
+// NewMemoryAliasStore returns an empty in-memory store.
+func NewMemoryAliasStore() *MemoryAliasStore {
+	return &MemoryAliasStore{
+		records: make(map[string][]byte),
+	}
+}
+
+func (s *MemoryAliasStore) Iterate(f func(key, value []byte) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for key, value := range s.records {
+		if err := f([]byte(key), slices.Clone(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *MemoryAliasStore) Write(batch []AliasStoreWrite) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	applyAliasStoreWrites(s.records, batch)
+	return nil
+}
+
+func applyAliasStoreWrites(records map[string][]byte, batch []AliasStoreWrite) {
+	for _, w := range batch {
+		if w.Value == nil {
+			delete(records, string(w.Key))
+		} else {
+			records[string(w.Key)] = slices.Clone(w.Value)
+		}
+	}
+}
+
+// FileAliasStore is an AliasStore held in a single JSON file. Every Write
+// rewrites the file to a temporary sibling and renames it into place, so a
+// crash leaves either the old or the new contents, never a mix. It is safe
+// for concurrent use within one process.
+type FileAliasStore struct {
+	path string
+
+	lock    sync.RWMutex
+	records map[string][]byte
+}
+
+// fileAliasStoreEntry is the on-disk form of one record. Keys and values
+// are base64-encoded by encoding/json, so any byte string round-trips.
+type fileAliasStoreEntry struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+// NewFileAliasStore opens the store at [path], which need not exist yet;
+// it is created on the first Write.
+func NewFileAliasStore(path string) (*FileAliasStore, error) {
+	s := &FileAliasStore{
+		path:    path,
+		records: make(map[string][]byte),
+	}
+	b, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return s, nil
+	case err != nil:
+		return nil, err
+	}
+
+	var entries []fileAliasStoreEntry
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("couldn't parse alias store %s: %w", path, err)
+	}
+	for _, e := range entries {
+		s.records[string(e.Key)] = e.Value
+	}
+	return s, nil
+}
+
+func (s *FileAliasStore) Iterate(f func(key, value []byte) error) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	for key, value := range s.records {
+		if err := f([]byte(key), slices.Clone(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *FileAliasStore) Write(batch []AliasStoreWrite) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	records := maps.Clone(s.records)
+	applyAliasStoreWrites(records, batch)
+	if err := s.save(records); err != nil {
+		return err
+	}
+	s.records = records
+	return nil
+}
+
+// save atomically replaces the file with [records], sorted by key so the
+// file is deterministic.
+func (s *FileAliasStore) save(records map[string][]byte) error {
+	entries := make([]fileAliasStoreEntry, 0, len(records))
+	for key, value := range records {
+		entries = append(entries, fileAliasStoreEntry{Key: []byte(key), Value: value})
+	}
+	slices.SortFunc(entries, func(a, b fileAliasStoreEntry) int {
+		return bytes.Compare(a.Key, b.Key)
+	})
+	b, err := json.MarshalIndent(entries, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	dir, base := filepath.Split(s.path)
+	if dir == "" {
+		dir = "."
+	}
+	f, err := os.CreateTemp(dir, base+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	_, err = f.Write(b)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, s.path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("couldn't write alias store %s: %w", s.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/ids/idstest"
+
+	. "github.com/luxfi/ids"
+)
+
+var errStoreFailed = errors.New("store failed")
+
+// failingAliasStore wraps an AliasStore and fails every Write while [fail]
+// is set.
+type failingAliasStore struct {
+	AliasStore
+	fail bool
+}
+
+func (s *failingAliasStore) Write(batch []AliasStoreWrite) error {
+	if s.fail {
+		return errStoreFailed
+	}
+	return s.AliasStore.Write(batch)
+}
+
+func TestPersistentAliaser(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		idstest.RunAllAlias(t, func() (AliaserReader, AliaserWriter) {
+			a, err := NewPersistentAliaser(NewMemoryAliasStore())
+			require.NoError(t, err)
+			return a, a
+		})
+		idstest.RunAllAliasEditor(t, func() (AliaserReader, AliaserEditor) {
+			a, err := NewPersistentAliaser(NewMemoryAliasStore())
+			require.NoError(t, err)
+			return a, a
+		})
+	})
+	t.Run("file", func(t *testing.T) {
+		newAliaser := func() PersistentAliaser {
+			store, err := NewFileAliasStore(filepath.Join(t.TempDir(), "aliases.json"))
+			require.NoError(t, err)
+			a, err := NewPersistentAliaser(store)
+			require.NoError(t, err)
+			return a
+		}
+		idstest.RunAllAlias(t, func() (AliaserReader, AliaserWriter) {
+			a := newAliaser()
+			return a, a
+		})
+		idstest.RunAllAliasEditor(t, func() (AliaserReader, AliaserEditor) {
+			a := newAliaser()
+			return a, a
+		})
+	})
+}
+
+func TestPersistentAliaserReload(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "aliases.json")
+	open := func() PersistentAliaser {
+		store, err := NewFileAliasStore(path)
+		require.NoError(err)
+		a, err := NewPersistentAliaser(store)
+		require.NoError(err)
+		return a
+	}
+
+	id1 := ID{'B', 'r', 'u', 'c', 'e', ' ', 'W', 'a', 'y', 'n', 'e'}
+	id2 := ID{'J', 'a', 'm', 'e', 's', ' ', 'G', 'o', 'r', 'd', 'o', 'n'}
+	a := open()
+	// Insertion order deliberately differs from lexical order.
+	require.NoError(a.Alias(id1, "Dark Knight"))
+	require.NoError(a.Alias(id2, "Commissioner"))
+	require.NoError(a.Alias(id1, "Batman"))
+	require.NoError(a.Alias(id1, "Arkham Knight"))
+
+	a = open()
+	aliases, err := a.Aliases(id1)
+	require.NoError(err)
+	require.Equal([]string{"Dark Knight", "Batman", "Arkham Knight"}, aliases)
+	require.Equal("Commissioner", a.PrimaryAliasOrDefault(id2))
+
+	// Aliases added after a reload keep their place after the loaded ones.
+	a.RemoveAliases(id2)
+	require.NoError(a.Alias(id2, "Jim"))
+	require.NoError(a.Alias(id1, "Bats"))
+	require.NoError(a.Err())
+
+	a = open()
+	aliases, err = a.Aliases(id1)
+	require.NoError(err)
+	require.Equal([]string{"Dark Knight", "Batman", "Arkham Knight", "Bats"}, aliases)
+	aliases, err = a.Aliases(id2)
+	require.NoError(err)
+	require.Equal([]string{"Jim"}, aliases)
+	_, err = a.Lookup("Commissioner")
+	require.ErrorIs(err, ErrNoIDWithAlias)
+}
+
+func TestPersistentAliaserStoreFailure(t *testing.T) {
+	require := require.New(t)
+
+	store := &failingAliasStore{AliasStore: NewMemoryAliasStore()}
+	a, err := NewPersistentAliaser(store)
+	require.NoError(err)
+
+	id := ID{'B', 'a', 'n', 'e'}
+	require.NoError(a.Alias(id, "Bane"))
+
+	store.fail = true
+	require.ErrorIs(a.Alias(id, "Venom"), errStoreFailed)
+	_, err = a.Lookup("Venom")
+	require.ErrorIs(err, ErrNoIDWithAlias, "a failed Alias must not be applied")
+
+	a.RemoveAliases(id)
+	require.ErrorIs(a.Err(), errStoreFailed)
+	require.Equal("Bane", a.PrimaryAliasOrDefault(id), "a failed RemoveAliases must not be applied")
+
+	store.fail = false
+	a.RemoveAliases(id)
+	require.ErrorIs(a.Err(), errStoreFailed, "a later success does not clear Err")
+	require.Equal(id.String(), a.PrimaryAliasOrDefault(id))
+
+	a.ResetErr()
+	require.NoError(a.Err())
+}
+
+func TestPersistentAliaserConsecutiveFailures(t *testing.T) {
+	require := require.New(t)
+
+	store := &failingAliasStore{AliasStore: NewMemoryAliasStore()}
+	a, err := NewPersistentAliaser(store)
+	require.NoError(err)
+
+	bane := ID{'B', 'a', 'n', 'e'}
+	joker := ID{'J', 'o', 'k', 'e', 'r'}
+	require.NoError(a.Alias(bane, "Bane"))
+	require.NoError(a.Alias(joker, "Joker"))
+
+	store.fail = true
+	a.RemoveAliases(bane)
+	require.ErrorIs(a.Err(), errStoreFailed)
+	require.Contains(a.Err().Error(), bane.String())
+
+	a.RemoveAliases(joker)
+	require.ErrorIs(a.Err(), errStoreFailed)
+	require.Contains(a.Err().Error(), joker.String(), "Err reports the latest failure")
+
+	// ReplaceAliases reports the failure of each call.
+	require.ErrorIs(a.ReplaceAliases(bane, nil), errStoreFailed)
+}
+
+func TestPersistentAliaserCorruptRecord(t *testing.T) {
+	store := NewMemoryAliasStore()
+	require.NoError(t, store.Write([]AliasStoreWrite{{Key: []byte("Batman"), Value: []byte{0x01}}}))
+
+	_, err := NewPersistentAliaser(store)
+	require.Error(t, err) //nolint:forbidigo // the corrupt-record error is unexported
+}
+
+func TestPersistentAliaserWithPolicy(t *testing.T) {
+	require := require.New(t)
+
+	store := NewMemoryAliasStore()
+	a, err := NewPersistentAliaser(store, WithAliasPolicy(DefaultAliasPolicy))
+	require.NoError(err)
+	require.ErrorIs(a.Alias(ID{'B', 'a', 'n', 'e'}, "P"), ErrAliasShadowsNativeChain)
+	require.NoError(a.Alias(PChainID, "platform"))
+
+	// A store written without the policy is checked when it is loaded.
+	a, err = NewPersistentAliaser(store)
+	require.NoError(err)
+	require.NoError(a.Alias(ID{'B', 'a', 'n', 'e'}, "P"))
+
+	_, err = NewPersistentAliaser(store, WithAliasPolicy(DefaultAliasPolicy))
+	require.ErrorIs(err, ErrAliasShadowsNativeChain)
+}
+
+func TestFileAliasStore(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "aliases.json")
+	store, err := NewFileAliasStore(path)
+	require.NoError(err)
+	_, err = os.Stat(path)
+	require.ErrorIs(err, os.ErrNotExist, "opening must not create the file")
+
+	require.NoError(store.Write([]AliasStoreWrite{
+		{Key: []byte("a"), Value: []byte{0x01}},
+		{Key: []byte("b"), Value: []byte{0x02}},
+	}))
+	require.NoError(store.Write([]AliasStoreWrite{{Key: []byte("a")}}))
+
+	reopened, err := NewFileAliasStore(path)
+	require.NoError(err)
+	records := make(map[string][]byte)
+	require.NoError(reopened.Iterate(func(key, value []byte) error {
+		records[string(key)] = value
+		return nil
+	}))
+	require.Equal(map[string][]byte{"b": {0x02}}, records)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(err)
+	require.Len(entries, 1, "no temporary files may be left behind")
+
+	require.NoError(os.WriteFile(path, []byte("not json"), 0o600))
+	_, err = NewFileAliasStore(path)
+	require.Error(err) //nolint:forbidigo // wraps a json syntax error
+}
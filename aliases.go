@@ -11,8 +11,8 @@ import (
 
 var (
 	ErrNoIDWithAlias      = errors.New("there is no ID with alias")
-	errNoAliasForID       = errors.New("there is no alias for ID")
-	errAliasAlreadyMapped = errors.New("alias already mapped to an ID")
+	ErrNoAliasForID       = errors.New("there is no alias for ID")
+	ErrAliasAlreadyMapped = errors.New("alias already mapped to an ID")
 )
 
 // AliaserReader allows one to lookup the aliases given to an ID.
@@ -49,23 +49,54 @@ type Aliaser interface {
 }
 
 type aliaser struct {
-	lock    sync.RWMutex
+	lock sync.RWMutex
+	// dealias is keyed by key(alias); aliases holds the aliases of each ID
+	// as they were spelled when added, primary alias first.
 	dealias map[string]ID
 	aliases map[ID][]string
+
+	// normalize, if non-nil, maps an alias to its dealias key.
+	normalize func(string) string
+
+	// store, if non-nil, receives every mutation before it is applied in
+	// memory. nextSeq is the sequence number of the next stored alias.
+	store   AliasStore
+	nextSeq uint64
+	// err is the latest error hit persisting RemoveAliases.
+	err error
+
+	// policy, if non-nil, is checked before an alias is added.
+	policy *AliasPolicy
+
+	events aliasEventQueue
 }
 
-func NewAliaser() Aliaser {
-	return &aliaser{
+// AliaserOption configures an aliaser created by NewAliaser,
+// NewAliaserWithPolicy or NewPersistentAliaser.
+type AliaserOption func(*aliaser)
+
+// NewAliaser returns an empty in-memory Aliaser. It also implements
+// AliaserEditor and AliasSubscriber.
+func NewAliaser(opts ...AliaserOption) Aliaser {
+	return newAliaser(opts...)
+}
+
+func newAliaser(opts ...AliaserOption) *aliaser {
+	a := &aliaser{
 		dealias: make(map[string]ID),
 		aliases: make(map[ID][]string),
 	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
 }
 
 func (a *aliaser) Lookup(alias string) (ID, error) {
 	a.lock.RLock()
 	defer a.lock.RUnlock()
 
-	if id, ok := a.dealias[alias]; ok {
+	if id, ok := a.dealias[a.key(alias)]; ok {
 		return id, nil
 	}
 	return ID{}, fmt.Errorf("%w: %s", ErrNoIDWithAlias, alias)
@@ -77,7 +108,7 @@ func (a *aliaser) PrimaryAlias(id ID) (string, error) {
 
 	aliases := a.aliases[id]
 	if len(aliases) == 0 {
-		return "", fmt.Errorf("%w: %s", errNoAliasForID, id)
+		return "", fmt.Errorf("%w: %s", ErrNoAliasForID, id)
 	}
 	return aliases[0], nil
 }
@@ -98,29 +129,92 @@ func (a *aliaser) Aliases(id ID) ([]string, error) {
 }
 
 func (a *aliaser) Alias(id ID, alias string) error {
+	defer a.events.deliver()
+
 	a.lock.Lock()
 	defer a.lock.Unlock()
 
-	if _, exists := a.dealias[alias]; exists {
-		return fmt.Errorf("%w: %s", errAliasAlreadyMapped, alias)
+	key := a.key(alias)
+	if existing, exists := a.dealias[key]; exists {
+		return a.clashError(existing, key, alias)
+	}
+	if err := a.checkPolicy(id, alias); err != nil {
+		return err
+	}
+	if a.store != nil {
+		err := a.store.Write([]AliasStoreWrite{{
+			Key:   []byte(alias),
+			Value: aliasRecord(a.nextSeq, id),
+		}})
+		if err != nil {
+			return fmt.Errorf("couldn't persist alias %s: %w", alias, err)
+		}
+		a.nextSeq++
 	}
 
-	a.dealias[alias] = id
+	a.dealias[key] = id
 	a.aliases[id] = append(a.aliases[id], alias)
+	a.events.queue(AliasAdded, id, alias)
 	return nil
 }
 
 func (a *aliaser) RemoveAliases(id ID) {
+	defer a.events.deliver()
+
 	a.lock.Lock()
 	defer a.lock.Unlock()
 
 	aliases := a.aliases[id]
+	if a.store != nil && len(aliases) > 0 {
+		batch := make([]AliasStoreWrite, len(aliases))
+		for i, alias := range aliases {
+			batch[i] = AliasStoreWrite{Key: []byte(alias)}
+		}
+		if err := a.store.Write(batch); err != nil {
+			a.err = fmt.Errorf("couldn't persist removal of aliases of %s: %w", id, err)
+			return
+		}
+	}
 	delete(a.aliases, id)
 	for _, alias := range aliases {
-		delete(a.dealias, alias)
+		delete(a.dealias, a.key(alias))
+		a.events.queue(AliasRemoved, id, alias)
 	}
 }
 
+// clashError returns the error for adding [alias], whose key [key] is
+// already mapped to [existing]. Under normalization the existing alias may
+// be spelled differently, in which case both spellings are reported.
+func (a *aliaser) clashError(existing ID, key, alias string) error {
+	if spelling := a.spelling(existing, key); spelling != alias {
+		return fmt.Errorf("%w: %s clashes with %s", ErrAliasAlreadyMapped, alias, spelling)
+	}
+	return fmt.Errorf("%w: %s", ErrAliasAlreadyMapped, alias)
+}
+
+// checkPolicy returns the error of this aliaser's policy for [alias], if
+// it has one. Under normalization, the policy also sees the key of [alias].
+func (a *aliaser) checkPolicy(id ID, alias string) error {
+	if a.policy == nil {
+		return nil
+	}
+	return a.policy.check(id, alias, a.normalize)
+}
+
+func (a *aliaser) Err() error {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	return a.err
+}
+
+func (a *aliaser) ResetErr() {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.err = nil
+}
+
 // GetRelevantAliases returns the aliases with the redundant identity alias
 // removed (each id is aliased to at least itself).
 func GetRelevantAliases(aliaser Aliaser, ids []ID) (map[ID][]string, error) {
@@ -20,6 +20,13 @@ func TestAliaser(t *testing.T) {
 	})
 }
 
+func TestAliaserEditor(t *testing.T) {
+	idstest.RunAllAliasEditor(t, func() (AliaserReader, AliaserEditor) {
+		a := NewAliaser()
+		return a, a.(AliaserEditor)
+	})
+}
+
 func TestPrimaryAliasOrDefaultTest(t *testing.T) {
 	require := require.New(t)
 	aliaser := NewAliaser()
@@ -35,3 +42,14 @@ func TestPrimaryAliasOrDefaultTest(t *testing.T) {
 	expected := "Batman"
 	require.Equal(expected, aliaser.PrimaryAliasOrDefault(id2))
 }
+
+func TestNormalizedAliaser(t *testing.T) {
+	idstest.RunAllAlias(t, func() (AliaserReader, AliaserWriter) {
+		a := NewAliaser(WithNormalizedLookup())
+		return a, a
+	})
+	idstest.RunAllAliasEditor(t, func() (AliaserReader, AliaserEditor) {
+		a := NewAliaser(WithNormalizedLookup())
+		return a, a.(AliaserEditor)
+	})
+}
@@ -0,0 +1,219 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// minBagSize is the initial capacity of a Bag's count map.
+const minBagSize = 16
+
+// BitKey is the set of identifier types whose bits can be addressed with the
+// index convention documented on EqualSubset. A NodeID is treated as the
+// first 20 bytes of an ID; its bits 160 through 255 are always zero.
+type BitKey interface {
+	ID | NodeID
+}
+
+// bitKey widens a BitKey to an ID so it can be fed to the bits.go helpers.
+func bitKey[T BitKey](v T) ID {
+	switch v := any(v).(type) {
+	case ID:
+		return v
+	case NodeID:
+		var id ID
+		copy(id[:], v[:])
+		return id
+	default:
+		panic("unreachable")
+	}
+}
+
+// Bag is a multiset. The zero value is an empty bag with a threshold of 0.
+//
+// Every element whose count reaches the threshold is tracked incrementally,
+// so Threshold is O(1) regardless of how many distinct elements have been
+// added. Consensus poll counting relies on this.
+type Bag[T comparable] struct {
+	counts map[T]int
+	size   int
+
+	threshold    int
+	metThreshold Set[T]
+}
+
+// BagOf returns a Bag containing [elts].
+func BagOf[T comparable](elts ...T) Bag[T] {
+	var b Bag[T]
+	b.Add(elts...)
+	return b
+}
+
+func (b *Bag[T]) init() {
+	if b.counts == nil {
+		b.counts = make(map[T]int, minBagSize)
+	}
+}
+
+// SetThreshold sets the number of times an element must be added to be
+// contained in the threshold set.
+func (b *Bag[T]) SetThreshold(threshold int) {
+	if b.threshold == threshold {
+		return
+	}
+
+	b.threshold = threshold
+	b.metThreshold.Clear()
+	for elt, count := range b.counts {
+		if count >= threshold {
+			b.metThreshold.Add(elt)
+		}
+	}
+}
+
+// Add increases the number of times each element has been seen by one.
+func (b *Bag[T]) Add(elts ...T) {
+	for _, elt := range elts {
+		b.AddCount(elt, 1)
+	}
+}
+
+// AddCount increases the number of times the element has been seen by
+// [count]. If [count] <= 0 this is a no-op.
+func (b *Bag[T]) AddCount(elt T, count int) {
+	if count <= 0 {
+		return
+	}
+
+	b.init()
+
+	totalCount := b.counts[elt] + count
+	b.counts[elt] = totalCount
+	b.size += count
+
+	if totalCount >= b.threshold {
+		b.metThreshold.Add(elt)
+	}
+}
+
+// Count returns the number of [elt] in the bag.
+func (b *Bag[T]) Count(elt T) int {
+	return b.counts[elt]
+}
+
+// Len returns the number of elements in the bag, counting duplicates.
+func (b *Bag[T]) Len() int {
+	return b.size
+}
+
+// List returns the distinct elements in the bag in unspecified order.
+func (b *Bag[T]) List() []T {
+	return slices.Collect(maps.Keys(b.counts))
+}
+
+// Equals returns true if the bags contain the same elements with the same
+// counts.
+func (b *Bag[T]) Equals(other Bag[T]) bool {
+	return b.size == other.size && maps.Equal(b.counts, other.counts)
+}
+
+// Mode returns the most common element in the bag and the count of that
+// element. If there's a tie, any of the tied elements may be returned. If
+// the bag is empty, the zero value and 0 are returned.
+func (b *Bag[T]) Mode() (T, int) {
+	var (
+		mode     T
+		modeFreq int
+	)
+	for elt, count := range b.counts {
+		if count > modeFreq {
+			mode = elt
+			modeFreq = count
+		}
+	}
+	return mode, modeFreq
+}
+
+// Threshold returns the elements that have been seen at least threshold
+// times. The returned set must not be modified.
+func (b *Bag[T]) Threshold() Set[T] {
+	return b.metThreshold
+}
+
+// Remove all instances of [elt] from the bag.
+func (b *Bag[T]) Remove(elt T) {
+	count := b.counts[elt]
+	delete(b.counts, elt)
+	b.size -= count
+	b.metThreshold.Remove(elt)
+}
+
+// Filter returns the bag of elements for which [filterFunc] returns true,
+// preserving their counts. The threshold is not carried over.
+func (b *Bag[T]) Filter(filterFunc func(T) bool) Bag[T] {
+	var newBag Bag[T]
+	for elt, count := range b.counts {
+		if filterFunc(elt) {
+			newBag.AddCount(elt, count)
+		}
+	}
+	return newBag
+}
+
+// Split returns two bags: index 0 holds the elements for which [partition]
+// returns false and index 1 the elements for which it returns true.
+func (b *Bag[T]) Split(partition func(T) bool) [2]Bag[T] {
+	var splitVotes [2]Bag[T]
+	for elt, count := range b.counts {
+		if partition(elt) {
+			splitVotes[1].AddCount(elt, count)
+		} else {
+			splitVotes[0].AddCount(elt, count)
+		}
+	}
+	return splitVotes
+}
+
+// Clone returns a copy of the bag, including its threshold.
+func (b *Bag[T]) Clone() Bag[T] {
+	var c Bag[T]
+	c.SetThreshold(b.threshold)
+	for elt, count := range b.counts {
+		c.AddCount(elt, count)
+	}
+	return c
+}
+
+func (b *Bag[T]) String() string {
+	sb := strings.Builder{}
+
+	sb.WriteString(fmt.Sprintf("Bag[%T]: (Size = %d)", *new(T), b.Len()))
+	for elt, count := range b.counts {
+		sb.WriteString(fmt.Sprintf("\n    %v: %d", elt, count))
+	}
+
+	return sb.String()
+}
+
+// FilterBag returns the elements of [b] that match [id] from bit [start] to
+// bit [end] (non-inclusive), preserving their counts. Bit indices follow
+// EqualSubset.
+func FilterBag[T BitKey](b *Bag[T], start, end int, id ID) Bag[T] {
+	return b.Filter(func(elt T) bool {
+		return EqualSubset(start, end, id, bitKey(elt))
+	})
+}
+
+// SplitBag partitions [b] on the value of bit [index] of each element, as
+// reported by ID.Bit. Index 0 of the result holds the elements whose bit is
+// 0 and index 1 those whose bit is 1.
+func SplitBag[T BitKey](b *Bag[T], index uint) [2]Bag[T] {
+	return b.Split(func(elt T) bool {
+		return bitKey(elt).Bit(index) == 1
+	})
+}
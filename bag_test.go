@@ -0,0 +1,105 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBagAddCount(t *testing.T) {
+	require := require.New(t)
+
+	id1 := ID{1}
+	id2 := ID{2}
+
+	var bag Bag[ID]
+	require.Zero(bag.Len())
+	require.Zero(bag.Count(id1))
+
+	bag.Add(id1)
+	bag.AddCount(id2, 5)
+	bag.AddCount(id2, 0)
+	bag.AddCount(id2, -1)
+	require.Equal(6, bag.Len())
+	require.Equal(1, bag.Count(id1))
+	require.Equal(5, bag.Count(id2))
+	require.ElementsMatch([]ID{id1, id2}, bag.List())
+
+	mode, freq := bag.Mode()
+	require.Equal(id2, mode)
+	require.Equal(5, freq)
+
+	bag.Remove(id2)
+	require.Equal(1, bag.Len())
+	require.Zero(bag.Count(id2))
+}
+
+func TestBagThreshold(t *testing.T) {
+	require := require.New(t)
+
+	id1 := NodeID{1}
+	id2 := NodeID{2}
+
+	var bag Bag[NodeID]
+	bag.SetThreshold(2)
+	bag.Add(id1, id2, id1)
+	require.Equal(SetOf(id1), bag.Threshold())
+
+	bag.Add(id2)
+	require.Equal(SetOf(id1, id2), bag.Threshold())
+
+	bag.SetThreshold(3)
+	require.Zero(bag.Threshold().Len())
+
+	bag.Remove(id1)
+	bag.SetThreshold(1)
+	require.Equal(SetOf(id2), bag.Threshold())
+
+	clone := bag.Clone()
+	require.True(clone.Equals(bag))
+	require.Equal(bag.Threshold(), clone.Threshold())
+}
+
+func TestBagFilterBits(t *testing.T) {
+	require := require.New(t)
+
+	id1 := ID{0x01}
+	id2 := ID{0x03}
+	id3 := ID{0x02}
+
+	bag := BagOf(id1, id2, id2, id3)
+
+	// Bit 0 of id1 and id2 is 1; bit 0 of id3 is 0.
+	filtered := FilterBag(&bag, 0, 1, ID{0x01})
+	require.Equal(3, filtered.Len())
+	require.Equal(1, filtered.Count(id1))
+	require.Equal(2, filtered.Count(id2))
+	require.Zero(filtered.Count(id3))
+
+	filtered = FilterBag(&bag, 0, 2, ID{0x03})
+	require.Equal(BagOf(id2, id2), filtered)
+}
+
+func TestBagSplitBit(t *testing.T) {
+	require := require.New(t)
+
+	id1 := NodeID{0x01}
+	id2 := NodeID{0x02}
+	id3 := NodeID{0x03}
+
+	bag := BagOf(id1, id2, id2, id3)
+	split := SplitBag(&bag, 1)
+	require.Equal(1, split[0].Len())
+	require.Equal(1, split[0].Count(id1))
+	require.Equal(3, split[1].Len())
+	require.Equal(2, split[1].Count(id2))
+	require.Equal(1, split[1].Count(id3))
+
+	// Bits beyond a NodeID's 160 are zero.
+	split = SplitBag(&bag, 200)
+	require.True(split[0].Equals(bag))
+	require.Zero(split[1].Len())
+}
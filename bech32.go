@@ -0,0 +1,376 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Address HRPs of the well-known networks. Any other HRP must be registered
+// with RegisterHRP before it can be formatted or parsed.
+const (
+	MainnetHRP = "lux"
+	TestnetHRP = "test"
+	LocalHRP   = "local"
+)
+
+const (
+	// addressSep separates the chain alias from the bech32 part of an
+	// address, as in "X-lux1...".
+	addressSep = "-"
+
+	bech32Charset   = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	bech32Sep       = '1'
+	bech32MaxLen    = 90
+	bech32ChecksumN = 6
+
+	// bech32Const and bech32mConst are the final polymod XOR constants of
+	// BIP-173 and BIP-350 respectively.
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+// Bech32Encoding selects the checksum variant of a bech32 string.
+type Bech32Encoding uint8
+
+const (
+	// Bech32 is the original BIP-173 checksum. Addresses use it.
+	Bech32 Bech32Encoding = iota + 1
+	// Bech32m is the BIP-350 checksum.
+	Bech32m
+)
+
+func (e Bech32Encoding) String() string {
+	switch e {
+	case Bech32:
+		return "bech32"
+	case Bech32m:
+		return "bech32m"
+	default:
+		return fmt.Sprintf("bech32-encoding(%d)", uint8(e))
+	}
+}
+
+func (e Bech32Encoding) checksumConst() (uint32, bool) {
+	switch e {
+	case Bech32:
+		return bech32Const, true
+	case Bech32m:
+		return bech32mConst, true
+	default:
+		return 0, false
+	}
+}
+
+// Typed address errors. Wrapped so consumers can match with errors.Is.
+var (
+	// ErrBech32Invalid — the string is not well-formed bech32: bad length,
+	// mixed case, a character outside the charset, a missing separator or
+	// non-zero padding.
+	ErrBech32Invalid = errors.New("ids: malformed bech32 string")
+
+	// ErrBech32Checksum — the string is well-formed but its checksum matches
+	// neither bech32 nor bech32m.
+	ErrBech32Checksum = errors.New("ids: invalid bech32 checksum")
+
+	// ErrUnknownHRP — the human-readable part is not registered.
+	ErrUnknownHRP = errors.New("ids: unknown bech32 HRP")
+
+	// ErrNoAddressSeparator — the address has no "<chain>-" prefix.
+	ErrNoAddressSeparator = errors.New("ids: no separator found in address")
+
+	// ErrUnknownChainAlias — the chain part of an address does not name a
+	// native chain or a CB58 chain ID.
+	ErrUnknownChainAlias = errors.New("ids: unknown chain alias")
+
+	// ErrAddressLength — the address is valid bech32 but its payload is not
+	// ShortIDLen bytes.
+	ErrAddressLength = errors.New("ids: address payload is not a ShortID")
+)
+
+var (
+	hrpLock sync.RWMutex
+	hrps    = map[string]struct{}{
+		MainnetHRP: {},
+		TestnetHRP: {},
+		LocalHRP:   {},
+	}
+)
+
+// RegisterHRP adds [hrp] to the set of HRPs accepted by FormatAddress and
+// ParseAddress. HRPs are lowercase; registering an HRP twice is a no-op.
+func RegisterHRP(hrp string) error {
+	if err := validateHRP(hrp); err != nil {
+		return err
+	}
+	if strings.ToLower(hrp) != hrp {
+		return fmt.Errorf("%w: HRP %q is not lowercase", ErrBech32Invalid, hrp)
+	}
+
+	hrpLock.Lock()
+	defer hrpLock.Unlock()
+
+	hrps[hrp] = struct{}{}
+	return nil
+}
+
+// unregisterHRP removes [hrp] from the set registered with RegisterHRP, so
+// tests can undo their registrations.
+func unregisterHRP(hrp string) {
+	hrpLock.Lock()
+	defer hrpLock.Unlock()
+
+	delete(hrps, hrp)
+}
+
+// IsKnownHRP reports whether [hrp] has been registered.
+func IsKnownHRP(hrp string) bool {
+	hrpLock.RLock()
+	defer hrpLock.RUnlock()
+
+	_, ok := hrps[hrp]
+	return ok
+}
+
+// FormatAddress returns "<chainAlias>-<bech32(hrp, id)>", e.g.
+// "X-lux1...". The bech32 part uses the BIP-173 checksum.
+func FormatAddress(chainAlias, hrp string, id ShortID) (string, error) {
+	if chainAlias == "" || strings.Contains(chainAlias, addressSep) {
+		return "", fmt.Errorf("%w: %q", ErrUnknownChainAlias, chainAlias)
+	}
+	if !IsKnownHRP(hrp) {
+		return "", fmt.Errorf("%w: %q", ErrUnknownHRP, hrp)
+	}
+	addr, err := EncodeBech32(hrp, id[:], Bech32)
+	if err != nil {
+		return "", err
+	}
+	return chainAlias + addressSep + addr, nil
+}
+
+// ParseAddress is the inverse of FormatAddress. It returns the chain alias,
+// the HRP and the ShortID of [addr]. Both bech32 and bech32m checksums are
+// accepted.
+func ParseAddress(addr string) (string, string, ShortID, error) {
+	chainAlias, rawAddr, ok := strings.Cut(addr, addressSep)
+	if !ok {
+		return "", "", ShortID{}, fmt.Errorf("%w: %q", ErrNoAddressSeparator, addr)
+	}
+	if chainAlias == "" {
+		return "", "", ShortID{}, fmt.Errorf("%w: %q", ErrUnknownChainAlias, chainAlias)
+	}
+
+	hrp, payload, _, err := DecodeBech32(rawAddr)
+	if err != nil {
+		return "", "", ShortID{}, err
+	}
+	if !IsKnownHRP(hrp) {
+		return "", "", ShortID{}, fmt.Errorf("%w: %q", ErrUnknownHRP, hrp)
+	}
+	id, err := ToShortID(payload)
+	if err != nil {
+		return "", "", ShortID{}, fmt.Errorf("%w: %w", ErrAddressLength, err)
+	}
+	return chainAlias, hrp, id, nil
+}
+
+// FormatChainAddress is FormatAddress with the chain given by ID. Native
+// chains use their letter (see NativeChainAlias); any other chain uses its
+// CB58 ID.
+func FormatChainAddress(chainID ID, hrp string, id ShortID) (string, error) {
+	chainAlias := NativeChainAlias(chainID)
+	if chainAlias == "" {
+		chainAlias = chainID.String()
+	}
+	return FormatAddress(chainAlias, hrp, id)
+}
+
+// ParseChainAddress is ParseAddress with the chain part resolved to an ID.
+// Native chain letters and strings, in either case, and CB58 chain IDs are
+// accepted; anything else is ErrUnknownChainAlias.
+func ParseChainAddress(addr string) (ID, string, ShortID, error) {
+	chainAlias, hrp, id, err := ParseAddress(addr)
+	if err != nil {
+		return ID{}, "", ShortID{}, err
+	}
+	chainID, err := FromString(chainAlias)
+	if err != nil {
+		return ID{}, "", ShortID{}, fmt.Errorf("%w: %q", ErrUnknownChainAlias, chainAlias)
+	}
+	return chainID, hrp, id, nil
+}
+
+// EncodeBech32 encodes [payload] under [hrp] with the [enc] checksum.
+// [hrp] must be lowercase.
+func EncodeBech32(hrp string, payload []byte, enc Bech32Encoding) (string, error) {
+	if err := validateHRP(hrp); err != nil {
+		return "", err
+	}
+	if strings.ToLower(hrp) != hrp {
+		return "", fmt.Errorf("%w: HRP %q is not lowercase", ErrBech32Invalid, hrp)
+	}
+	checksumConst, ok := enc.checksumConst()
+	if !ok {
+		return "", fmt.Errorf("%w: unknown encoding %s", ErrBech32Invalid, enc)
+	}
+
+	data := convertBits8To5(payload)
+	if len(hrp)+1+len(data)+bech32ChecksumN > bech32MaxLen {
+		return "", fmt.Errorf("%w: encoded length exceeds %d", ErrBech32Invalid, bech32MaxLen)
+	}
+
+	values := append(bech32HRPExpand(hrp), data...)
+	values = append(values, make([]byte, bech32ChecksumN)...)
+	polymod := bech32Polymod(values) ^ checksumConst
+
+	sb := strings.Builder{}
+	sb.Grow(len(hrp) + 1 + len(data) + bech32ChecksumN)
+	sb.WriteString(hrp)
+	sb.WriteByte(bech32Sep)
+	for _, v := range data {
+		sb.WriteByte(bech32Charset[v])
+	}
+	for i := 0; i < bech32ChecksumN; i++ {
+		sb.WriteByte(bech32Charset[(polymod>>(5*(5-i)))&31])
+	}
+	return sb.String(), nil
+}
+
+// DecodeBech32 is the inverse of EncodeBech32. It returns the lowercase HRP,
+// the payload and the checksum variant that matched.
+func DecodeBech32(s string) (string, []byte, Bech32Encoding, error) {
+	hrp, data, enc, err := decodeBech32Data(s)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	payload, err := convertBits5To8(data)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	return hrp, payload, enc, nil
+}
+
+// decodeBech32Data validates [s] and returns its HRP and 5-bit data values,
+// without the checksum.
+func decodeBech32Data(s string) (string, []byte, Bech32Encoding, error) {
+	if len(s) > bech32MaxLen {
+		return "", nil, 0, fmt.Errorf("%w: length %d exceeds %d", ErrBech32Invalid, len(s), bech32MaxLen)
+	}
+	lower := strings.ToLower(s)
+	if lower != s && strings.ToUpper(s) != s {
+		return "", nil, 0, fmt.Errorf("%w: mixed case", ErrBech32Invalid)
+	}
+
+	sep := strings.LastIndexByte(lower, bech32Sep)
+	if sep < 1 || sep+1+bech32ChecksumN > len(lower) {
+		return "", nil, 0, fmt.Errorf("%w: invalid separator position", ErrBech32Invalid)
+	}
+
+	hrp := lower[:sep]
+	if err := validateHRP(hrp); err != nil {
+		return "", nil, 0, err
+	}
+
+	data := make([]byte, 0, len(lower)-sep-1)
+	for i := sep + 1; i < len(lower); i++ {
+		v := strings.IndexByte(bech32Charset, lower[i])
+		if v < 0 {
+			return "", nil, 0, fmt.Errorf("%w: invalid character %q", ErrBech32Invalid, lower[i])
+		}
+		data = append(data, byte(v))
+	}
+
+	var enc Bech32Encoding
+	switch bech32Polymod(append(bech32HRPExpand(hrp), data...)) {
+	case bech32Const:
+		enc = Bech32
+	case bech32mConst:
+		enc = Bech32m
+	default:
+		return "", nil, 0, ErrBech32Checksum
+	}
+	return hrp, data[:len(data)-bech32ChecksumN], enc, nil
+}
+
+func validateHRP(hrp string) error {
+	if len(hrp) == 0 {
+		return fmt.Errorf("%w: empty HRP", ErrBech32Invalid)
+	}
+	for i := 0; i < len(hrp); i++ {
+		if c := hrp[i]; c < 33 || c > 126 {
+			return fmt.Errorf("%w: invalid HRP character 0x%02x", ErrBech32Invalid, c)
+		}
+	}
+	return nil
+}
+
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i, g := range generator {
+			if (top>>i)&1 == 1 {
+				chk ^= g
+			}
+		}
+	}
+	return chk
+}
+
+func bech32HRPExpand(hrp string) []byte {
+	out := make([]byte, 0, 2*len(hrp)+1)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]>>5)
+	}
+	out = append(out, 0)
+	for i := 0; i < len(hrp); i++ {
+		out = append(out, hrp[i]&31)
+	}
+	return out
+}
+
+// convertBits8To5 regroups bytes into 5-bit values, zero padding the last
+// group.
+func convertBits8To5(payload []byte) []byte {
+	out := make([]byte, 0, (len(payload)*8+4)/5)
+	var acc uint32
+	bits := 0
+	for _, b := range payload {
+		acc = acc<<8 | uint32(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out = append(out, byte(acc>>bits)&31)
+		}
+	}
+	if bits > 0 {
+		out = append(out, byte(acc<<(5-bits))&31)
+	}
+	return out
+}
+
+// convertBits5To8 regroups 5-bit values into bytes. Padding must be fewer
+// than 5 bits and all zero, so every payload has exactly one encoding.
+func convertBits5To8(data []byte) ([]byte, error) {
+	out := make([]byte, 0, len(data)*5/8)
+	var acc uint32
+	bits := 0
+	for _, v := range data {
+		acc = acc<<5 | uint32(v)
+		bits += 5
+		if bits >= 8 {
+			bits -= 8
+			out = append(out, byte(acc>>bits))
+		}
+	}
+	if bits >= 5 || acc&(1<<bits-1) != 0 {
+		return nil, fmt.Errorf("%w: invalid padding", ErrBech32Invalid)
+	}
+	return out, nil
+}
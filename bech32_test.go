@@ -0,0 +1,166 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestBech32Vectors checks the valid and invalid checksum vectors from
+// BIP-173 and BIP-350.
+func TestBech32Vectors(t *testing.T) {
+	valid := []struct {
+		str string
+		enc Bech32Encoding
+	}{
+		{"A12UEL5L", Bech32},
+		{"a12uel5l", Bech32},
+		{"an83characterlonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1tt5tgs", Bech32},
+		{"abcdef1qpzry9x8gf2tvdw0s3jn54khce6mua7lmqqqxw", Bech32},
+		{"11" + strings.Repeat("q", 82) + "c8247j", Bech32},
+		{"split1checkupstagehandshakeupstreamerranterredcaperred2y9e3w", Bech32},
+		{"?1ezyfcl", Bech32},
+		{"A1LQFN3A", Bech32m},
+		{"a1lqfn3a", Bech32m},
+		{"abcdef1l7aum6echk45nj3s0wdvt2fg8x9yrzpqzd3ryx", Bech32m},
+		{"split1checkupstagehandshakeupstreamerranterredcaperredlc445v", Bech32m},
+		{"?1v759aa", Bech32m},
+	}
+	for _, tt := range valid {
+		t.Run(tt.str, func(t *testing.T) {
+			hrp, _, enc, err := decodeBech32Data(tt.str)
+			require.NoError(t, err)
+			require.Equal(t, tt.enc, enc)
+			require.Equal(t, strings.ToLower(tt.str[:strings.LastIndexByte(tt.str, '1')]), hrp)
+		})
+	}
+
+	invalid := []struct {
+		str         string
+		expectedErr error
+	}{
+		{"\x201nwldj5", ErrBech32Invalid},
+		{"\x7f1axkwrx", ErrBech32Invalid},
+		{"an84characterslonghumanreadablepartthatcontainsthenumber1andtheexcludedcharactersbio1569pvx", ErrBech32Invalid},
+		{"pzry9x0s0muk", ErrBech32Invalid},
+		{"1pzry9x0s0muk", ErrBech32Invalid},
+		{"x1b4n0q5v", ErrBech32Invalid},
+		{"li1dgmt3", ErrBech32Invalid},
+		{"de1lg7wt\xff", ErrBech32Invalid},
+		{"A1G7SGD8", ErrBech32Checksum},
+		{"M1VUXWEZ", ErrBech32Checksum},
+		{"10a06t8", ErrBech32Invalid},
+		{"1qzzfhee", ErrBech32Invalid},
+		{"a12UEL5L", ErrBech32Invalid},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.str, func(t *testing.T) {
+			_, _, _, err := decodeBech32Data(tt.str)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestBech32RoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	payload := []byte{0x00, 0x01, 0xfe, 0xff, 0x7f}
+	for _, enc := range []Bech32Encoding{Bech32, Bech32m} {
+		s, err := EncodeBech32("lux", payload, enc)
+		require.NoError(err)
+
+		hrp, got, gotEnc, err := DecodeBech32(strings.ToUpper(s))
+		require.NoError(err)
+		require.Equal("lux", hrp)
+		require.Equal(payload, got)
+		require.Equal(enc, gotEnc)
+	}
+
+	_, err := EncodeBech32("LUX", payload, Bech32)
+	require.ErrorIs(err, ErrBech32Invalid)
+	_, err = EncodeBech32("lux", payload, 0)
+	require.ErrorIs(err, ErrBech32Invalid)
+	_, err = EncodeBech32("lux", make([]byte, 64), Bech32)
+	require.ErrorIs(err, ErrBech32Invalid)
+}
+
+func TestAddressRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	id := ShortID{0x3c, 0xb7, 0xd3, 0x84, 0x2e, 0x8c, 0xee, 0x6a, 0x0e, 0xbd, 0x09, 0xf1, 0xfe, 0x88, 0x4f, 0x68, 0x61, 0xe1, 0xb2, 0x9c}
+	addr, err := FormatAddress("X", MainnetHRP, id)
+	require.NoError(err)
+	require.True(strings.HasPrefix(addr, "X-lux1"), addr)
+
+	chainAlias, hrp, parsed, err := ParseAddress(addr)
+	require.NoError(err)
+	require.Equal("X", chainAlias)
+	require.Equal(MainnetHRP, hrp)
+	require.Equal(id, parsed)
+
+	chainID, hrp, parsed, err := ParseChainAddress(addr)
+	require.NoError(err)
+	require.Equal(XChainID, chainID)
+	require.Equal(MainnetHRP, hrp)
+	require.Equal(id, parsed)
+
+	addr, err = FormatChainAddress(PChainID, TestnetHRP, id)
+	require.NoError(err)
+	require.True(strings.HasPrefix(addr, "P-test1"), addr)
+
+	customChain := ID{1, 2, 3}
+	addr, err = FormatChainAddress(customChain, LocalHRP, id)
+	require.NoError(err)
+	chainID, _, _, err = ParseChainAddress(addr)
+	require.NoError(err)
+	require.Equal(customChain, chainID)
+}
+
+func TestAddressErrors(t *testing.T) {
+	require := require.New(t)
+
+	id := ShortID{1}
+	_, err := FormatAddress("X", "nope", id)
+	require.ErrorIs(err, ErrUnknownHRP)
+	_, err = FormatAddress("", MainnetHRP, id)
+	require.ErrorIs(err, ErrUnknownChainAlias)
+
+	_, _, _, err = ParseAddress("lux1qqqqqq")
+	require.ErrorIs(err, ErrNoAddressSeparator)
+
+	addr, err := FormatAddress("X", MainnetHRP, id)
+	require.NoError(err)
+
+	// Flip the last checksum character.
+	last := addr[len(addr)-1]
+	flipped := byte('q')
+	if last == 'q' {
+		flipped = 'p'
+	}
+	_, _, _, err = ParseAddress(addr[:len(addr)-1] + string(flipped))
+	require.ErrorIs(err, ErrBech32Checksum)
+
+	unknown, err := EncodeBech32("nope", id[:], Bech32)
+	require.NoError(err)
+	_, _, _, err = ParseAddress("X-" + unknown)
+	require.ErrorIs(err, ErrUnknownHRP)
+
+	require.NoError(RegisterHRP("nope"))
+	t.Cleanup(func() { unregisterHRP("nope") })
+	_, _, parsed, err := ParseAddress("X-" + unknown)
+	require.NoError(err)
+	require.Equal(id, parsed)
+	require.ErrorIs(RegisterHRP("Nope"), ErrBech32Invalid)
+
+	long, err := EncodeBech32(MainnetHRP, make([]byte, 21), Bech32)
+	require.NoError(err)
+	_, _, _, err = ParseAddress("X-" + long)
+	require.ErrorIs(err, ErrAddressLength)
+
+	_, _, _, err = ParseChainAddress("Y-" + unknown)
+	require.ErrorIs(err, ErrUnknownChainAlias)
+}
@@ -0,0 +1,208 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/crypto/hash"
+)
+
+var (
+	_ encoding.BinaryMarshaler   = ID{}
+	_ encoding.BinaryAppender    = ID{}
+	_ encoding.TextAppender      = ID{}
+	_ encoding.BinaryUnmarshaler = (*ID)(nil)
+
+	_ encoding.BinaryMarshaler   = ShortID{}
+	_ encoding.BinaryAppender    = ShortID{}
+	_ encoding.TextAppender      = ShortID{}
+	_ encoding.BinaryUnmarshaler = (*ShortID)(nil)
+
+	_ encoding.BinaryMarshaler   = NodeID{}
+	_ encoding.BinaryAppender    = NodeID{}
+	_ encoding.TextAppender      = NodeID{}
+	_ encoding.BinaryUnmarshaler = (*NodeID)(nil)
+
+	_ encoding.BinaryMarshaler   = TypedNodeID{}
+	_ encoding.BinaryAppender    = TypedNodeID{}
+	_ encoding.TextAppender      = TypedNodeID{}
+	_ encoding.BinaryUnmarshaler = (*TypedNodeID)(nil)
+
+	_ encoding.BinaryMarshaler   = FullDigest{}
+	_ encoding.BinaryAppender    = FullDigest{}
+	_ encoding.TextAppender      = FullDigest{}
+	_ encoding.BinaryUnmarshaler = (*FullDigest)(nil)
+
+	_ encoding.BinaryMarshaler   = RequestID{}
+	_ encoding.BinaryAppender    = RequestID{}
+	_ encoding.BinaryUnmarshaler = (*RequestID)(nil)
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       encoding.BinaryAppender
+		dst         encoding.BinaryUnmarshaler
+		expectedLen int
+	}{
+		{"ID", ID{1, 2, 3}, new(ID), IDLen},
+		{"ShortID", ShortID{4, 5, 6}, new(ShortID), ShortIDLen},
+		{"NodeID", NodeID{7, 8, 9}, new(NodeID), NodeIDLen},
+		{"TypedNodeID", TypedNodeID{Scheme: NodeIDSchemeMLDSA87, NodeID: NodeID{1}}, new(TypedNodeID), TypedNodeIDLen},
+		{"FullDigest", FullDigest{0xff, 47: 0x01}, new(FullDigest), FullDigestLen},
+		{"RequestID", RequestID{NodeID: NodeID{1}, SourceChainID: PChainID, DestinationChainID: XChainID, RequestID: 7, Op: 3}, new(RequestID), RequestIDLen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			b, err := tt.value.(encoding.BinaryMarshaler).MarshalBinary()
+			require.NoError(err)
+			require.Len(b, tt.expectedLen)
+
+			prefix := []byte("prefix")
+			appended, err := tt.value.AppendBinary(slices.Clone(prefix))
+			require.NoError(err)
+			require.Equal(append(prefix, b...), appended)
+
+			require.NoError(tt.dst.UnmarshalBinary(b))
+			require.Equal(b, must(tt.dst.(encoding.BinaryMarshaler).MarshalBinary()))
+
+			require.Error(tt.dst.UnmarshalBinary(b[1:]))        //nolint:forbidigo // each type wraps its own length error
+			require.Error(tt.dst.UnmarshalBinary(append(b, 0))) //nolint:forbidigo // each type wraps its own length error
+		})
+	}
+}
+
+func must(b []byte, err error) []byte {
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestBinaryErrors(t *testing.T) {
+	require := require.New(t)
+
+	id := ID{1}
+	require.ErrorIs(id.UnmarshalBinary([]byte{1}), hash.ErrInvalidHashLen)
+	require.Equal(ID{1}, id, "a failed unmarshal must not modify the receiver")
+
+	var digest FullDigest
+	require.ErrorIs(digest.UnmarshalBinary(make([]byte, FullDigestLen-1)), ErrFullDigestLen)
+
+	// Binary, text and SQL report the same error for the same scheme.
+	for scheme, expectedErr := range map[NodeIDScheme]error{
+		NodeIDSchemeInvalid: ErrNodeIDSchemeInvalid,
+		0x91:                ErrNodeIDSchemeUnknown,
+	} {
+		typed := TypedNodeID{Scheme: scheme}
+		b, err := typed.MarshalBinary()
+		require.ErrorIs(err, expectedErr, "scheme %s", scheme)
+		require.Nil(b)
+		b, err = typed.AppendBinary([]byte("prefix"))
+		require.ErrorIs(err, expectedErr, "scheme %s", scheme)
+		require.Equal([]byte("prefix"), b, "a failed append must return the input")
+		_, err = typed.AppendText(nil)
+		require.ErrorIs(err, expectedErr, "scheme %s", scheme)
+		_, err = typed.Value()
+		require.ErrorIs(err, expectedErr, "scheme %s", scheme)
+	}
+	var typed TypedNodeID
+	require.ErrorIs(typed.UnmarshalBinary(make([]byte, TypedNodeIDLen)), ErrNodeIDSchemeUnknown)
+
+	var r RequestID
+	require.ErrorIs(r.UnmarshalBinary(make([]byte, RequestIDLen-1)), ErrRequestIDLen)
+	b := make([]byte, RequestIDLen)
+	b[0] = requestIDCodecVersion + 1
+	require.ErrorIs(r.UnmarshalBinary(b), ErrRequestIDVersion)
+}
+
+// TestRequestIDBinaryLayout pins the version 0 wire layout.
+func TestRequestIDBinaryLayout(t *testing.T) {
+	require := require.New(t)
+
+	r := RequestID{
+		NodeID:             NodeID{0x01},
+		SourceChainID:      ID{0x02},
+		DestinationChainID: ID{0x03},
+		RequestID:          0x04050607,
+		Op:                 0x08,
+	}
+	b, err := r.MarshalBinary()
+	require.NoError(err)
+
+	expected := slices.Concat(
+		[]byte{0x00},
+		r.NodeID[:],
+		r.SourceChainID[:],
+		r.DestinationChainID[:],
+		[]byte{0x04, 0x05, 0x06, 0x07},
+		[]byte{0x08},
+	)
+	require.Equal(expected, b)
+}
+
+func TestAppendText(t *testing.T) {
+	require := require.New(t)
+
+	id := ID{1}
+	b, err := id.AppendText([]byte("id="))
+	require.NoError(err)
+	require.Equal("id="+id.String(), string(b))
+
+	b, err = ShortID{1}.AppendText(nil)
+	require.NoError(err)
+	require.Equal(ShortID{1}.String(), string(b))
+
+	b, err = NodeID{1}.AppendText(nil)
+	require.NoError(err)
+	require.Equal(NodeID{1}.String(), string(b))
+}
+
+func TestBinaryAppendAllocs(t *testing.T) {
+	buf := make([]byte, 0, 256)
+	id := ID{1}
+	typed := TypedNodeID{Scheme: NodeIDSchemeMLDSA65}
+	r := RequestID{}
+	allocs := testing.AllocsPerRun(100, func() {
+		b, _ := id.AppendBinary(buf[:0])
+		b, _ = typed.AppendBinary(b)
+		_, _ = r.AppendBinary(b)
+	})
+	require.Zero(t, allocs)
+}
+
+func TestBinaryGob(t *testing.T) {
+	require := require.New(t)
+
+	type record struct {
+		ID      ID
+		NodeID  NodeID
+		Typed   TypedNodeID
+		Digest  FullDigest
+		Request RequestID
+	}
+	in := record{
+		ID:      ID{1},
+		NodeID:  NodeID{2},
+		Typed:   TypedNodeID{Scheme: NodeIDSchemeSecp256k1, NodeID: NodeID{3}},
+		Digest:  FullDigest{4},
+		Request: RequestID{NodeID: NodeID{5}, RequestID: 6},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(gob.NewEncoder(&buf).Encode(in))
+
+	var out record
+	require.NoError(gob.NewDecoder(&buf).Decode(&out))
+	require.Equal(in, out)
+}
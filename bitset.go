@@ -0,0 +1,160 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+	"strings"
+)
+
+// bitsPerWord is the number of bits packed into each word of a Bits.
+const bitsPerWord = 64
+
+// Bits is a growable set of small non-negative integers, such as validator
+// indices. The zero value is an empty set ready to use.
+//
+// Bit i is stored at word i/64, position i%64, counting from the least
+// significant bit: the same LSB-first ordering that EqualSubset and ID.Bit
+// use within a byte.
+type Bits struct {
+	words []uint64
+}
+
+// NewBits returns a Bits containing [indices].
+func NewBits(indices ...uint) Bits {
+	var b Bits
+	for _, i := range indices {
+		b.Add(i)
+	}
+	return b
+}
+
+// Add sets bit [i].
+func (b *Bits) Add(i uint) {
+	word := int(i / bitsPerWord)
+	if word >= len(b.words) {
+		b.words = append(b.words, make([]uint64, word+1-len(b.words))...)
+	}
+	b.words[word] |= 1 << (i % bitsPerWord)
+}
+
+// Remove clears bit [i].
+func (b *Bits) Remove(i uint) {
+	word := int(i / bitsPerWord)
+	if word >= len(b.words) {
+		return
+	}
+	b.words[word] &^= 1 << (i % bitsPerWord)
+	b.trim()
+}
+
+// Contains returns true iff bit [i] is set.
+func (b Bits) Contains(i uint) bool {
+	word := int(i / bitsPerWord)
+	if word >= len(b.words) {
+		return false
+	}
+	return b.words[word]&(1<<(i%bitsPerWord)) != 0
+}
+
+// Union sets every bit that is set in [other].
+func (b *Bits) Union(other Bits) {
+	if len(other.words) > len(b.words) {
+		b.words = append(b.words, make([]uint64, len(other.words)-len(b.words))...)
+	}
+	for i, w := range other.words {
+		b.words[i] |= w
+	}
+}
+
+// Intersection clears every bit that is not set in [other].
+func (b *Bits) Intersection(other Bits) {
+	for i := range b.words {
+		if i < len(other.words) {
+			b.words[i] &= other.words[i]
+		} else {
+			b.words[i] = 0
+		}
+	}
+	b.trim()
+}
+
+// Difference clears every bit that is set in [other].
+func (b *Bits) Difference(other Bits) {
+	for i := range min(len(b.words), len(other.words)) {
+		b.words[i] &^= other.words[i]
+	}
+	b.trim()
+}
+
+// Clear unsets all bits.
+func (b *Bits) Clear() {
+	b.words = b.words[:0]
+}
+
+// Len returns the number of set bits.
+func (b Bits) Len() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// BitLen returns the index of the highest set bit plus one, or 0 if no bit
+// is set.
+func (b Bits) BitLen() int {
+	if len(b.words) == 0 {
+		return 0
+	}
+	last := len(b.words) - 1
+	return last*bitsPerWord + bits.Len64(b.words[last])
+}
+
+// List returns the indices of the set bits in ascending order.
+func (b Bits) List() []uint {
+	list := make([]uint, 0, b.Len())
+	for i, w := range b.words {
+		for w != 0 {
+			list = append(list, uint(i*bitsPerWord+bits.TrailingZeros64(w)))
+			w &= w - 1
+		}
+	}
+	return list
+}
+
+// Equals returns true iff both sets contain the same bits.
+func (b Bits) Equals(other Bits) bool {
+	return slices.Equal(b.words, other.words)
+}
+
+// Clone returns a copy of this set that shares no memory with it.
+func (b Bits) Clone() Bits {
+	return Bits{words: slices.Clone(b.words)}
+}
+
+// String returns the set bits as a big-endian hex string, e.g. "0x5" for
+// {0, 2}.
+func (b Bits) String() string {
+	if len(b.words) == 0 {
+		return "0x0"
+	}
+	sb := strings.Builder{}
+	sb.WriteString(fmt.Sprintf("0x%x", b.words[len(b.words)-1]))
+	for i := len(b.words) - 2; i >= 0; i-- {
+		sb.WriteString(fmt.Sprintf("%016x", b.words[i]))
+	}
+	return sb.String()
+}
+
+// trim drops trailing zero words so equal sets have equal representations.
+func (b *Bits) trim() {
+	n := len(b.words)
+	for n > 0 && b.words[n-1] == 0 {
+		n--
+	}
+	b.words = b.words[:n]
+}
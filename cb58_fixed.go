@@ -0,0 +1,191 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"crypto/sha256"
+	"slices"
+)
+
+// Fixed-size CB58.
+//
+// CB58 is base58(payload || sha256(payload)[28:32]). The cb58 package
+// handles arbitrary lengths and allocates for the checked payload, the
+// checksum and the base58 conversion. Every payload this package encodes
+// is at most FullDigestLen bytes, so the whole conversion fits in stack
+// buffers: appendCB58 and decodeCB58 never allocate, and produce and accept
+// exactly the same strings as cb58.Encode and cb58.Decode.
+//
+// decodeCB58 only reports success or failure. Callers fall back to
+// cb58.Decode on failure to produce the same typed errors as before;
+// invalid input is rare, so the slow path costs nothing in practice.
+
+const (
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+	cb58ChecksumLen = 4
+	// cb58MaxPayload is the largest payload encoded by this package.
+	cb58MaxPayload = FullDigestLen
+	// cb58MaxLen bounds the encoded length of cb58MaxPayload bytes:
+	// ceil((48 + 4) * log(256) / log(58)) = 72.
+	cb58MaxLen = 72
+
+	// base58Limb is 58^5, the radix of the limbs used for the conversion.
+	// Five base58 digits fit in a uint32 limb, and limb*256 + carry fits
+	// in a uint64.
+	base58Limb       = 58 * 58 * 58 * 58 * 58
+	base58LimbDigits = 5
+	base58MaxLimbs   = (cb58MaxLen + base58LimbDigits - 1) / base58LimbDigits
+
+	invalidBase58Digit = 0xff
+)
+
+// base58Digits maps an ASCII character to its base58 value, or
+// invalidBase58Digit.
+var base58Digits = func() [256]byte {
+	var digits [256]byte
+	for i := range digits {
+		digits[i] = invalidBase58Digit
+	}
+	for i := 0; i < len(base58Alphabet); i++ {
+		digits[base58Alphabet[i]] = byte(i)
+	}
+	return digits
+}()
+
+// appendCB58 appends the CB58 encoding of [payload] to [dst]. [payload]
+// must be at most cb58MaxPayload bytes.
+func appendCB58(dst []byte, payload []byte) []byte {
+	var checked [cb58MaxPayload + cb58ChecksumLen]byte
+	n := copy(checked[:], payload)
+	sum := sha256.Sum256(payload)
+	copy(checked[n:], sum[sha256.Size-cb58ChecksumLen:])
+	return appendBase58(dst, checked[:n+cb58ChecksumLen])
+}
+
+// appendBase58 appends the base58 encoding of [in] to [dst]. Leading zero
+// bytes are encoded as '1', as in every Bitcoin-style base58 encoder.
+func appendBase58(dst []byte, in []byte) []byte {
+	zeros := 0
+	for zeros < len(in) && in[zeros] == 0 {
+		zeros++
+	}
+
+	// Little-endian limbs of the big-endian number in[zeros:].
+	var limbs [base58MaxLimbs]uint32
+	numLimbs := 0
+	for _, b := range in[zeros:] {
+		carry := uint64(b)
+		for i := 0; i < numLimbs; i++ {
+			carry += uint64(limbs[i]) << 8
+			limbs[i] = uint32(carry % base58Limb)
+			carry /= base58Limb
+		}
+		for carry > 0 {
+			limbs[numLimbs] = uint32(carry % base58Limb)
+			numLimbs++
+			carry /= base58Limb
+		}
+	}
+
+	// Expand the limbs into digits, least significant first.
+	var digits [base58MaxLimbs * base58LimbDigits]byte
+	numDigits := 0
+	for i := 0; i < numLimbs; i++ {
+		limb := limbs[i]
+		for j := 0; j < base58LimbDigits; j++ {
+			digits[numDigits] = byte(limb % 58)
+			numDigits++
+			limb /= 58
+		}
+	}
+	// The top limb is zero-padded; those digits are not part of the
+	// encoding.
+	for numDigits > 0 && digits[numDigits-1] == 0 {
+		numDigits--
+	}
+
+	dst = slices.Grow(dst, zeros+numDigits)
+	for i := 0; i < zeros; i++ {
+		dst = append(dst, base58Alphabet[0])
+	}
+	for i := numDigits - 1; i >= 0; i-- {
+		dst = append(dst, base58Alphabet[digits[i]])
+	}
+	return dst
+}
+
+// decodeCB58 decodes the CB58 string [s] into [dst], which must be at most
+// cb58MaxPayload bytes. It reports whether [s] encodes exactly len(dst)
+// bytes with a valid checksum; [dst] is only written on success.
+func decodeCB58(dst []byte, s string) bool {
+	var checked [cb58MaxPayload + cb58ChecksumLen]byte
+	out := checked[:len(dst)+cb58ChecksumLen]
+	if !decodeBase58(out, s) {
+		return false
+	}
+
+	payload := out[:len(dst)]
+	sum := sha256.Sum256(payload)
+	if [cb58ChecksumLen]byte(out[len(dst):]) != [cb58ChecksumLen]byte(sum[sha256.Size-cb58ChecksumLen:]) {
+		return false
+	}
+	copy(dst, payload)
+	return true
+}
+
+// decodeBase58 decodes [s] into [out] and reports whether [s] encodes
+// exactly len(out) bytes, leading zero bytes included.
+func decodeBase58(out []byte, s string) bool {
+	if len(s) == 0 || len(s) > cb58MaxLen {
+		return false
+	}
+
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	if zeros > len(out) {
+		return false
+	}
+
+	// Accumulate s[zeros:] into big-endian 32-bit words, five digits at a
+	// time.
+	var words [(cb58MaxPayload + cb58ChecksumLen + 3) / 4]uint32
+	w := words[:(len(out)+3)/4]
+	for i := zeros; i < len(s); {
+		mul, acc := uint64(1), uint64(0)
+		for end := min(i+base58LimbDigits, len(s)); i < end; i++ {
+			digit := base58Digits[s[i]]
+			if digit == invalidBase58Digit {
+				return false
+			}
+			mul *= 58
+			acc = acc*58 + uint64(digit)
+		}
+		for j := len(w) - 1; j >= 0; j-- {
+			acc += uint64(w[j]) * mul
+			w[j] = uint32(acc)
+			acc >>= 32
+		}
+		if acc != 0 {
+			return false
+		}
+	}
+	// When len(out) is not a multiple of 4, the top word only has room for
+	// len(out)%4 bytes.
+	if extra := len(out) % 4; extra != 0 && w[0]>>(8*extra) != 0 {
+		return false
+	}
+	for k := range out {
+		out[len(out)-1-k] = byte(w[len(w)-1-k/4] >> (8 * (k % 4)))
+	}
+
+	// The value must occupy exactly the bytes after the leading zeros.
+	leading := 0
+	for leading < len(out) && out[leading] == 0 {
+		leading++
+	}
+	return leading == zeros
+}
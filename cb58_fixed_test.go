@@ -0,0 +1,242 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/crypto/cb58"
+)
+
+// cb58TestPayloads returns payloads of every length up to cb58MaxPayload:
+// all zeros, all 0xff, leading zeros of every length, and random bytes.
+func cb58TestPayloads() [][]byte {
+	rng := rand.New(rand.NewSource(58)) //#nosec G404
+	var payloads [][]byte
+	for n := 0; n <= cb58MaxPayload; n++ {
+		payloads = append(payloads,
+			make([]byte, n),
+			bytes.Repeat([]byte{0xff}, n),
+		)
+		for zeros := 0; zeros < n; zeros++ {
+			p := make([]byte, n)
+			rng.Read(p[zeros:])
+			p[zeros] |= 1
+			payloads = append(payloads, p)
+		}
+	}
+	return payloads
+}
+
+func TestAppendCB58MatchesCB58(t *testing.T) {
+	require := require.New(t)
+
+	for _, payload := range cb58TestPayloads() {
+		expected, err := cb58.Encode(payload)
+		require.NoError(err)
+		require.Equal(expected, string(appendCB58(nil, payload)), "payload %x", payload)
+
+		decoded := make([]byte, len(payload))
+		require.True(decodeCB58(decoded, expected), "payload %x", payload)
+		require.Equal(payload, decoded)
+	}
+}
+
+func TestAppendStringMatchesString(t *testing.T) {
+	require := require.New(t)
+
+	id := ID{0x01, 0x02, 31: 0x03}
+	short := ShortID{0x00, 0x00, 0x04, 19: 0x05}
+	nodeID := NodeID{0x06, 19: 0x07}
+
+	expectedID, err := cb58.Encode(id[:])
+	require.NoError(err)
+	expectedShort, err := cb58.Encode(short[:])
+	require.NoError(err)
+
+	prefix := []byte("prefix:")
+	require.Equal(expectedID, id.String())
+	require.Equal("prefix:"+expectedID, string(id.AppendString(prefix)))
+	require.Equal(expectedShort, short.String())
+	require.Equal("prefix:"+expectedShort, string(short.AppendString(prefix)))
+	require.Equal("prefix:"+nodeID.String(), string(nodeID.AppendString(prefix)))
+	require.Equal("prefix:"+PChainIDStr, string(PChainID.AppendString(prefix)))
+	require.Equal("prefix:", string(prefix), "AppendString must not write into [dst]'s contents")
+}
+
+func TestDecodeCB58Rejects(t *testing.T) {
+	id := ID{0x01, 31: 0x02}
+	idStr := id.String()
+	shortStr := ShortID{0x01}.String()
+	tests := []struct {
+		name string
+		in   string
+	}{
+		{"empty", ""},
+		{"too short for checksum", "foo"},
+		{"bad checksum", "foobar"},
+		{"not base58", "0" + idStr[1:]},
+		{"non-ASCII", "é" + idStr[2:]},
+		{"short payload", shortStr},
+		{"extra leading zero", "1" + idStr},
+		{"trailing character", idStr + "1"},
+		{"all zeros", "11111111111111111111111111111111111111"},
+		{"too long", idStr + idStr + idStr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var decoded ID
+			require.False(t, decodeCB58(decoded[:], tt.in))
+			require.Equal(t, ID{}, decoded)
+		})
+	}
+}
+
+func TestCB58FastPathAllocs(t *testing.T) {
+	require := require.New(t)
+
+	id := ID{0x01, 31: 0x02}
+	short := ShortID{0x03, 19: 0x04}
+	nodeID := NodeID{0x05, 19: 0x06}
+	idStr, shortStr, nodeIDStr := id.String(), short.String(), nodeID.String()
+
+	buf := make([]byte, 0, len(NodeIDPrefix)+cb58MaxLen)
+	tests := map[string]func(){
+		"ID.AppendString":      func() { _ = id.AppendString(buf) },
+		"ShortID.AppendString": func() { _ = short.AppendString(buf) },
+		"NodeID.AppendString":  func() { _ = nodeID.AppendString(buf) },
+		"FromString":           func() { _, _ = FromString(idStr) },
+		"ShortFromString":      func() { _, _ = ShortFromString(shortStr) },
+		"NodeIDFromString":     func() { _, _ = NodeIDFromString(nodeIDStr) },
+	}
+	for name, f := range tests {
+		require.Zero(testing.AllocsPerRun(100, f), name)
+	}
+	// The returned string is the only allocation.
+	require.Equal(1.0, testing.AllocsPerRun(100, func() { _ = id.String() }))
+}
+
+// FuzzCB58Encode checks the fast encoder and decoder against cb58 for
+// arbitrary payloads.
+func FuzzCB58Encode(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(make([]byte, IDLen))
+	f.Add(bytes.Repeat([]byte{0xff}, FullDigestLen))
+	f.Add([]byte{0x00, 0x00, 0x01})
+
+	f.Fuzz(func(t *testing.T, payload []byte) {
+		if len(payload) > cb58MaxPayload {
+			payload = payload[:cb58MaxPayload]
+		}
+		expected, err := cb58.Encode(payload)
+		require.NoError(t, err)
+		encoded := appendCB58(nil, payload)
+		require.Equal(t, expected, string(encoded))
+
+		decoded := make([]byte, len(payload))
+		require.True(t, decodeCB58(decoded, expected))
+		require.Equal(t, payload, decoded)
+	})
+}
+
+// FuzzCB58Decode checks that the fast decoder accepts exactly the strings
+// cb58.Decode accepts for each fixed size, with the same result.
+func FuzzCB58Decode(f *testing.F) {
+	f.Add("")
+	f.Add("foobar")
+	f.Add(ID{0x01}.String())
+	f.Add(ShortID{0x01}.String())
+	f.Add(FullDigest{0x01}.String())
+	f.Add("1" + ID{}.String())
+
+	f.Fuzz(func(t *testing.T, s string) {
+		expected, err := cb58.Decode(s)
+		for _, size := range []int{ShortIDLen, IDLen, FullDigestLen} {
+			decoded := make([]byte, size)
+			ok := decodeCB58(decoded, s)
+			require.Equal(t, err == nil && len(expected) == size, ok, "size %d", size)
+			if ok {
+				require.Equal(t, expected, decoded)
+			}
+		}
+	})
+}
+
+func BenchmarkIDString(b *testing.B) {
+	id := ID{0x01, 31: 0x02}
+	b.Run("cb58", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_, _ = cb58.Encode(id[:])
+		}
+	})
+	b.Run("String", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = id.String()
+		}
+	})
+	b.Run("AppendString", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]byte, 0, cb58MaxLen)
+		for b.Loop() {
+			buf = id.AppendString(buf[:0])
+		}
+	})
+}
+
+func BenchmarkIDFromString(b *testing.B) {
+	idStr := ID{0x01, 31: 0x02}.String()
+	b.Run("cb58", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			bytes, _ := cb58.Decode(idStr)
+			_, _ = ToID(bytes)
+		}
+	})
+	b.Run("FromString", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_, _ = FromString(idStr)
+		}
+	})
+}
+
+func BenchmarkShortIDString(b *testing.B) {
+	id := ShortID{0x01, 19: 0x02}
+	b.Run("cb58", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_, _ = cb58.Encode(id[:])
+		}
+	})
+	b.Run("AppendString", func(b *testing.B) {
+		b.ReportAllocs()
+		buf := make([]byte, 0, cb58MaxLen)
+		for b.Loop() {
+			buf = id.AppendString(buf[:0])
+		}
+	})
+}
+
+func BenchmarkShortFromString(b *testing.B) {
+	idStr := ShortID{0x01, 19: 0x02}.String()
+	b.Run("cb58", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			bytes, _ := cb58.Decode(idStr)
+			_, _ = ToShortID(bytes)
+		}
+	})
+	b.Run("ShortFromString", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_, _ = ShortFromString(idStr)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNoChainSeparator — a chain-qualified ID has no "<chain>-" prefix.
+var ErrNoChainSeparator = errors.New("ids: no chain separator found in chain-qualified ID")
+
+// ChainQualifiedString returns [id] scoped to the chain [chainID] as
+// "<chainAlias>-<id>", e.g. "X-2JVSBoinj9C2J33VntvzYtVJNZdN2NKiwwKjcumHUWEb5DbBrm".
+//
+// The chain alias is the native chain letter if [chainID] is a native chain,
+// otherwise the primary alias in [r], otherwise the CB58 chain ID. [r] may be
+// nil.
+func ChainQualifiedString(chainID, id ID, r AliaserReader) string {
+	chainAlias := NativeChainAlias(chainID)
+	if chainAlias == "" && r != nil {
+		chainAlias, _ = r.PrimaryAlias(chainID)
+	}
+	if chainAlias == "" {
+		chainAlias = chainID.String()
+	}
+	return id.PrefixedString(chainAlias + addressSep)
+}
+
+// ParseChainQualified is the inverse of ChainQualifiedString. It returns the
+// chain ID and the ID of [s].
+//
+// The string is split at its last "-": neither CB58 nor native chain strings
+// contain one, while aliases such as "x-chain" may. The chain part is
+// resolved as a native chain (see NativeChainFromString), then through [r]
+// if it is non-nil, then as a CB58 chain ID.
+func ParseChainQualified(s string, r AliaserReader) (ID, ID, error) {
+	sep := strings.LastIndex(s, addressSep)
+	if sep < 0 {
+		return ID{}, ID{}, fmt.Errorf("%w: %q", ErrNoChainSeparator, s)
+	}
+	chainAlias, idStr := s[:sep], s[sep+len(addressSep):]
+
+	chainID, err := resolveChainAlias(chainAlias, r)
+	if err != nil {
+		return ID{}, ID{}, err
+	}
+	id, err := FromString(idStr)
+	if err != nil {
+		return ID{}, ID{}, err
+	}
+	return chainID, id, nil
+}
+
+func resolveChainAlias(chainAlias string, r AliaserReader) (ID, error) {
+	if chainID, ok := NativeChainFromString(chainAlias); ok {
+		return chainID, nil
+	}
+	if r != nil {
+		if chainID, err := r.Lookup(chainAlias); err == nil {
+			return chainID, nil
+		}
+	}
+	if chainAlias != "" {
+		if chainID, err := FromString(chainAlias); err == nil {
+			return chainID, nil
+		}
+	}
+	return ID{}, fmt.Errorf("%w: %q", ErrUnknownChainAlias, chainAlias)
+}
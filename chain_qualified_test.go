@@ -0,0 +1,123 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIDPrefixedString(t *testing.T) {
+	id := ID{'a', 'v', 'a', ' ', 'l', 'a', 'b', 's'}
+	require.Equal(t, "P-"+id.String(), id.PrefixedString("P-"))
+}
+
+func TestChainQualifiedRoundTrip(t *testing.T) {
+	txID := ID{'t', 'x'}
+	customChain := ID{'c', 'u', 's', 't', 'o', 'm'}
+
+	aliaser := NewAliaser()
+	require.NoError(t, aliaser.Alias(customChain, "my-chain"))
+
+	tests := []struct {
+		name     string
+		chainID  ID
+		r        AliaserReader
+		expected string
+	}{
+		{
+			name:     "native chain",
+			chainID:  XChainID,
+			r:        aliaser,
+			expected: "X-" + txID.String(),
+		},
+		{
+			name:     "aliased chain",
+			chainID:  customChain,
+			r:        aliaser,
+			expected: "my-chain-" + txID.String(),
+		},
+		{
+			name:     "no aliaser",
+			chainID:  customChain,
+			expected: customChain.String() + "-" + txID.String(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			s := ChainQualifiedString(tt.chainID, txID, tt.r)
+			require.Equal(tt.expected, s)
+
+			chainID, id, err := ParseChainQualified(s, tt.r)
+			require.NoError(err)
+			require.Equal(tt.chainID, chainID)
+			require.Equal(txID, id)
+		})
+	}
+}
+
+func TestParseChainQualified(t *testing.T) {
+	txID := ID{'t', 'x'}
+
+	aliaser := NewAliaser()
+	require.NoError(t, aliaser.Alias(CChainID, "evm"))
+
+	tests := []struct {
+		name            string
+		in              string
+		r               AliaserReader
+		expectedChainID ID
+		expectedErr     error
+	}{
+		{
+			name:            "lowercase native letter",
+			in:              "p-" + txID.String(),
+			expectedChainID: PChainID,
+		},
+		{
+			name:            "full native string",
+			in:              CChainIDStr + "-" + txID.String(),
+			expectedChainID: CChainID,
+		},
+		{
+			name:            "aliaser",
+			in:              "evm-" + txID.String(),
+			r:               aliaser,
+			expectedChainID: CChainID,
+		},
+		{
+			name:        "alias without aliaser",
+			in:          "evm-" + txID.String(),
+			expectedErr: ErrUnknownChainAlias,
+		},
+		{
+			name:        "empty chain",
+			in:          "-" + txID.String(),
+			expectedErr: ErrUnknownChainAlias,
+		},
+		{
+			name:        "no separator",
+			in:          txID.String(),
+			expectedErr: ErrNoChainSeparator,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			chainID, id, err := ParseChainQualified(tt.in, tt.r)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				require.Equal(tt.expectedChainID, chainID)
+				require.Equal(txID, id)
+			}
+		})
+	}
+
+	_, _, err := ParseChainQualified("X-notcb58", nil)
+	require.Error(t, err) //nolint:forbidigo // cb58 errors are wrapped
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/luxfi/crypto/cb58"
+)
+
+// fullDigestHexLen is the length of the hex form of a FullDigest, without
+// the optional "0x" prefix.
+const fullDigestHexLen = 2 * FullDigestLen
+
+var (
+	// ErrFullDigestLen — input was not exactly FullDigestLen bytes.
+	ErrFullDigestLen = errors.New("ids: FullDigest length mismatch")
+
+	_ Sortable[FullDigest] = FullDigest{}
+)
+
+// ToFullDigest attempts to convert a byte slice into a FullDigest.
+func ToFullDigest(b []byte) (FullDigest, error) {
+	var d FullDigest
+	if len(b) != FullDigestLen {
+		return d, fmt.Errorf("%w: got %d bytes, want %d", ErrFullDigestLen, len(b), FullDigestLen)
+	}
+	copy(d[:], b)
+	return d, nil
+}
+
+// MarshalBinary returns the raw 48 bytes of this digest.
+func (d FullDigest) MarshalBinary() ([]byte, error) {
+	return d.AppendBinary(make([]byte, 0, FullDigestLen))
+}
+
+// AppendBinary appends the raw 48 bytes of this digest to [b].
+func (d FullDigest) AppendBinary(b []byte) ([]byte, error) {
+	return append(b, d[:]...), nil
+}
+
+// UnmarshalBinary is the inverse of MarshalBinary. The input must be exactly
+// FullDigestLen bytes.
+func (d *FullDigest) UnmarshalBinary(b []byte) error {
+	parsed, err := ToFullDigest(b)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// FullDigestFromString is the inverse of FullDigest.String. A CB58 payload
+// of the wrong length is refused with ErrFullDigestLen.
+func FullDigestFromString(s string) (FullDigest, error) {
+	var d FullDigest
+	if decodeCB58(d[:], s) {
+		return d, nil
+	}
+	b, err := cb58.Decode(s)
+	if err != nil {
+		return FullDigest{}, fmt.Errorf("couldn't decode FullDigest to bytes: %w", err)
+	}
+	return ToFullDigest(b)
+}
+
+// FullDigestFromHex is the inverse of FullDigest.Hex. An optional "0x"
+// prefix is accepted. Input of the wrong length is refused with
+// ErrFullDigestLen.
+func FullDigestFromHex(s string) (FullDigest, error) {
+	s = trimHexPrefix(s)
+	if len(s) != fullDigestHexLen {
+		return FullDigest{}, fmt.Errorf("%w: got %d hex characters, want %d",
+			ErrFullDigestLen, len(s), fullDigestHexLen)
+	}
+	var d FullDigest
+	if _, err := hex.Decode(d[:], []byte(s)); err != nil {
+		return FullDigest{}, fmt.Errorf("couldn't decode FullDigest hex: %w", err)
+	}
+	return d, nil
+}
+
+// String returns the CB58 encoding of this digest. This is the canonical
+// text form used by MarshalText and JSON.
+func (d FullDigest) String() string {
+	var buf [cb58MaxLen]byte
+	return string(appendCB58(buf[:0], d[:]))
+}
+
+// Hex returns the lowercase hex encoding of this digest, without a "0x"
+// prefix.
+func (d FullDigest) Hex() string {
+	return hex.EncodeToString(d[:])
+}
+
+// MarshalText returns the CB58 String form of this digest. JSON encodes a
+// FullDigest through this method, as a string.
+func (d FullDigest) MarshalText() ([]byte, error) {
+	return d.AppendText(make([]byte, 0, cb58MaxLen))
+}
+
+// AppendText appends the CB58 String form of this digest to [b].
+func (d FullDigest) AppendText(b []byte) ([]byte, error) {
+	return appendCB58(b, d[:]), nil
+}
+
+// UnmarshalText decodes the CB58 String form. Hex, with or without a "0x"
+// prefix, is also accepted so that files written with hex digests keep
+// loading; CB58 never starts with "0" and is never as long as the hex form,
+// so the two cannot be confused. Empty input and "null" decode to the zero
+// digest.
+func (d *FullDigest) UnmarshalText(text []byte) error {
+	s := string(text)
+	var (
+		parsed FullDigest
+		err    error
+	)
+	switch {
+	case s == "" || s == nullStr:
+	case trimHexPrefix(s) != s || len(s) == fullDigestHexLen:
+		parsed, err = FullDigestFromHex(s)
+	default:
+		parsed, err = FullDigestFromString(s)
+	}
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Compare orders digests lexicographically by byte.
+func (d FullDigest) Compare(other FullDigest) int {
+	return bytes.Compare(d[:], other[:])
+}
+
+// Equal reports whether [d] and [other] are equal, in constant time. Use it
+// instead of == when either side comes from a peer.
+func (d FullDigest) Equal(other FullDigest) bool {
+	return subtle.ConstantTimeCompare(d[:], other[:]) == 1
+}
+
+// IsZero returns true if the digest is all zeros.
+func (d FullDigest) IsZero() bool {
+	return d == FullDigest{}
+}
+
+// NodeID returns the 20-byte NodeID this digest truncates to.
+func (d FullDigest) NodeID() NodeID {
+	return NodeID(d[:NodeIDLen])
+}
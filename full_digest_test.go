@@ -0,0 +1,116 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/crypto/cb58"
+)
+
+func TestFullDigestString(t *testing.T) {
+	require := require.New(t)
+
+	_, d, err := NodeIDSchemeMLDSA65.DeriveMLDSA(ID{}, []byte("key"))
+	require.NoError(err)
+
+	s := d.String()
+	parsed, err := FullDigestFromString(s)
+	require.NoError(err)
+	require.Equal(d, parsed)
+
+	h := d.Hex()
+	require.Len(h, 2*FullDigestLen)
+	require.Equal(strings.ToLower(h), h)
+	parsed, err = FullDigestFromHex(h)
+	require.NoError(err)
+	require.Equal(d, parsed)
+	parsed, err = FullDigestFromHex("0x" + strings.ToUpper(h))
+	require.NoError(err)
+	require.Equal(d, parsed)
+
+	b, err := d.AppendText([]byte("digest="))
+	require.NoError(err)
+	require.Equal("digest="+s, string(b))
+}
+
+func TestFullDigestParseErrors(t *testing.T) {
+	require := require.New(t)
+
+	short, err := cb58.Encode(make([]byte, FullDigestLen-1))
+	require.NoError(err)
+	_, err = FullDigestFromString(short)
+	require.ErrorIs(err, ErrFullDigestLen)
+
+	_, err = FullDigestFromString("not cb58")
+	require.Error(err) //nolint:forbidigo // cb58 errors are wrapped
+
+	_, err = FullDigestFromHex(strings.Repeat("00", FullDigestLen-1))
+	require.ErrorIs(err, ErrFullDigestLen)
+	_, err = FullDigestFromHex("0x" + strings.Repeat("00", FullDigestLen+1))
+	require.ErrorIs(err, ErrFullDigestLen)
+
+	_, err = FullDigestFromHex(strings.Repeat("zz", FullDigestLen))
+	require.Error(err) //nolint:forbidigo // hex errors are wrapped
+}
+
+func TestFullDigestJSON(t *testing.T) {
+	require := require.New(t)
+
+	type transcript struct {
+		Digest FullDigest `json:"digest"`
+	}
+	in := transcript{Digest: FullDigest{1, 2, 3, 47: 0xff}}
+
+	b, err := json.Marshal(in)
+	require.NoError(err)
+	require.JSONEq(`{"digest":"`+in.Digest.String()+`"}`, string(b))
+
+	var out transcript
+	require.NoError(json.Unmarshal(b, &out))
+	require.Equal(in, out)
+
+	// Hex-encoded digests from older files still load.
+	out = transcript{}
+	require.NoError(json.Unmarshal([]byte(`{"digest":"0x`+in.Digest.Hex()+`"}`), &out))
+	require.Equal(in, out)
+	out = transcript{}
+	require.NoError(json.Unmarshal([]byte(`{"digest":"`+in.Digest.Hex()+`"}`), &out))
+	require.Equal(in, out)
+
+	out = transcript{Digest: FullDigest{1}}
+	require.NoError(json.Unmarshal([]byte(`{"digest":""}`), &out))
+	require.True(out.Digest.IsZero())
+
+	require.ErrorIs(json.Unmarshal([]byte(`{"digest":"0x00"}`), &out), ErrFullDigestLen)
+}
+
+func TestFullDigestCompareEqual(t *testing.T) {
+	require := require.New(t)
+
+	a := FullDigest{1}
+	b := FullDigest{1, 47: 1}
+
+	require.Equal(0, a.Compare(a))
+	require.Equal(-1, a.Compare(b))
+	require.Equal(1, b.Compare(a))
+
+	require.True(a.Equal(a))
+	require.False(a.Equal(b))
+
+	require.True(FullDigest{}.IsZero())
+	require.False(b.IsZero())
+}
+
+func TestFullDigestNodeID(t *testing.T) {
+	require := require.New(t)
+
+	typed, d, err := TypedNodeIDFromMLDSA(NodeIDSchemeMLDSA87, ID{1}, []byte("key"))
+	require.NoError(err)
+	require.Equal(typed.NodeID, d.NodeID())
+}
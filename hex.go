@@ -0,0 +1,109 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/luxfi/crypto/hash"
+)
+
+// IDFormat is the string encoding Parse detected.
+type IDFormat uint8
+
+const (
+	// IDFormatUnknown is returned when no format matched.
+	IDFormatUnknown IDFormat = iota
+	// IDFormatNative is a native chain string or letter, e.g. "P".
+	IDFormatNative
+	// IDFormatHex is 64 hex characters, optionally prefixed by "0x".
+	IDFormatHex
+	// IDFormatCB58 is the CB58 encoding returned by ID.String.
+	IDFormatCB58
+)
+
+// String returns the name of this format.
+func (f IDFormat) String() string {
+	switch f {
+	case IDFormatNative:
+		return "native"
+	case IDFormatHex:
+		return "hex"
+	case IDFormatCB58:
+		return "cb58"
+	default:
+		return "unknown"
+	}
+}
+
+// FromHex is the inverse of ID.Hex. An optional "0x" or "0X" prefix and
+// mixed case are accepted; anything but exactly 64 hex digits is refused,
+// with hash.ErrInvalidHashLen for the wrong length.
+func FromHex(s string) (ID, error) {
+	var id ID
+	err := decodeFixedHex(id[:], s)
+	return id, err
+}
+
+// ShortFromHex is the inverse of ShortID.Hex. See FromHex; the input must
+// be exactly 40 hex digits.
+func ShortFromHex(s string) (ShortID, error) {
+	var id ShortID
+	err := decodeFixedHex(id[:], s)
+	return id, err
+}
+
+// NodeIDFromHex parses the 40 hex digits of a NodeID, as printed by
+// EVM tooling and relayer logs. See FromHex.
+func NodeIDFromHex(s string) (NodeID, error) {
+	var id NodeID
+	err := decodeFixedHex(id[:], s)
+	return id, err
+}
+
+// Parse parses an ID in any of its string encodings and reports which one
+// it found: a native chain string or letter (see NativeChainFromString),
+// hex as accepted by FromHex, or CB58. The encodings cannot be confused:
+// CB58 never contains "0" and a CB58 ID is never 64 characters long.
+//
+// On failure the returned format is the one whose decoding failed.
+func Parse(s string) (ID, IDFormat, error) {
+	if id, ok := NativeChainFromString(s); ok {
+		return id, IDFormatNative, nil
+	}
+	if trimHexPrefix(s) != s || len(s) == 2*IDLen {
+		id, err := FromHex(s)
+		return id, IDFormatHex, err
+	}
+	id, err := FromString(s)
+	if err != nil {
+		return ID{}, IDFormatCB58, err
+	}
+	return id, IDFormatCB58, nil
+}
+
+// decodeFixedHex decodes [s], with an optional 0x prefix, into exactly
+// len(dst) bytes. [dst] is left unmodified on error.
+func decodeFixedHex(dst []byte, s string) error {
+	s = trimHexPrefix(s)
+	if len(s) != 2*len(dst) {
+		return fmt.Errorf("%w: got %d hex characters, want %d",
+			hash.ErrInvalidHashLen, len(s), 2*len(dst))
+	}
+	var buf [IDLen]byte
+	b := buf[:len(dst)]
+	if _, err := hex.Decode(b, []byte(s)); err != nil {
+		return fmt.Errorf("couldn't decode hex: %w", err)
+	}
+	copy(dst, b)
+	return nil
+}
+
+func trimHexPrefix(s string) string {
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		return s[2:]
+	}
+	return s
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/crypto/hash"
+)
+
+func TestFromHex(t *testing.T) {
+	id := ID{0xde, 0xad, 0xbe, 0xef, 31: 0x01}
+	tests := []struct {
+		name        string
+		in          string
+		expectedErr error
+	}{
+		{"plain", id.Hex(), nil},
+		{"0x prefix", "0x" + id.Hex(), nil},
+		{"0X prefix", "0X" + id.Hex(), nil},
+		{"upper case", strings.ToUpper(id.Hex()), nil},
+		{"mixed case", "0xDeAdBeEf" + id.Hex()[8:], nil},
+		{"too short", id.Hex()[2:], hash.ErrInvalidHashLen},
+		{"too long", id.Hex() + "00", hash.ErrInvalidHashLen},
+		{"prefix only", "0x", hash.ErrInvalidHashLen},
+		{"empty", "", hash.ErrInvalidHashLen},
+		{"double prefix", "0x0x" + id.Hex()[2:], hex.InvalidByteError('x')},
+		{"not hex", "zz" + id.Hex()[2:], hex.InvalidByteError('z')},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			parsed, err := FromHex(tt.in)
+			require.ErrorIs(err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				require.Equal(id, parsed)
+			} else {
+				require.Equal(ID{}, parsed)
+			}
+		})
+	}
+}
+
+func TestShortAndNodeIDFromHex(t *testing.T) {
+	require := require.New(t)
+
+	short := ShortID{0xab, 19: 0xcd}
+	parsed, err := ShortFromHex("0x" + strings.ToUpper(short.Hex()))
+	require.NoError(err)
+	require.Equal(short, parsed)
+	_, err = ShortFromHex(ID{}.Hex())
+	require.ErrorIs(err, hash.ErrInvalidHashLen)
+
+	nodeID := NodeID{0x01, 19: 0x02}
+	parsedNodeID, err := NodeIDFromHex("0x" + hex.EncodeToString(nodeID[:]))
+	require.NoError(err)
+	require.Equal(nodeID, parsedNodeID)
+	_, err = NodeIDFromHex(nodeID.String())
+	require.ErrorIs(err, hash.ErrInvalidHashLen)
+}
+
+func TestParse(t *testing.T) {
+	id := ID{'p', 'a', 'r', 's', 'e'}
+	tests := []struct {
+		name           string
+		in             string
+		expectedID     ID
+		expectedFormat IDFormat
+		expectErr      bool
+	}{
+		{"native letter", "x", XChainID, IDFormatNative, false},
+		{"native string", PChainID.String(), PChainID, IDFormatNative, false},
+		{"native as hex", PChainID.Hex(), PChainID, IDFormatHex, false},
+		{"hex", id.Hex(), id, IDFormatHex, false},
+		{"0x hex", "0x" + strings.ToUpper(id.Hex()), id, IDFormatHex, false},
+		{"cb58", id.String(), id, IDFormatCB58, false},
+		{"bad hex", "0x1234", ID{}, IDFormatHex, true},
+		{"bad cb58", "not an id", ID{}, IDFormatCB58, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			parsed, format, err := Parse(tt.in)
+			if tt.expectErr {
+				require.Error(err) //nolint:forbidigo // hex and cb58 errors are wrapped
+			} else {
+				require.NoError(err)
+			}
+			require.Equal(tt.expectedID, parsed)
+			require.Equal(tt.expectedFormat, format)
+		})
+	}
+}
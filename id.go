@@ -56,6 +56,11 @@ func FromString(idStr string) (ID, error) {
 		return id, nil
 	}
 
+	var id ID
+	if decodeCB58(id[:], idStr) {
+		return id, nil
+	}
+	// Slow path: reproduce the cb58 error for malformed input.
 	bytes, err := cb58.Decode(idStr)
 	if err != nil {
 		return ID{}, err
@@ -97,16 +102,10 @@ func FromStringOrPanic(idStr string) ID {
 }
 
 func (id ID) MarshalJSON() ([]byte, error) {
-	// Check if this is a well-known native chain ID
-	if nativeStr := NativeChainString(id); nativeStr != "" {
-		return []byte(`"` + nativeStr + `"`), nil
-	}
-
-	str, err := cb58.Encode(id[:])
-	if err != nil {
-		return nil, err
-	}
-	return []byte(`"` + str + `"`), nil
+	b := make([]byte, 0, cb58MaxLen+2)
+	b = append(b, '"')
+	b = id.AppendString(b)
+	return append(b, '"'), nil
 }
 
 func (id *ID) UnmarshalJSON(b []byte) error {
@@ -135,6 +134,9 @@ func (id *ID) UnmarshalJSON(b []byte) error {
 		return nil
 	}
 
+	if decodeCB58(id[:], innerStr) {
+		return nil
+	}
 	// Parse CB58 formatted string to bytes
 	bytes, err := cb58.Decode(innerStr)
 	if err != nil {
@@ -166,6 +168,9 @@ func (id *ID) UnmarshalText(text []byte) error {
 		*id = nativeID
 		return nil
 	}
+	if decodeCB58(id[:], str) {
+		return nil
+	}
 	bytes, err := cb58.Decode(str)
 	if err != nil {
 		return fmt.Errorf("couldn't decode ID to bytes: %w", err)
@@ -263,15 +268,57 @@ func (id ID) String() string {
 	if nativeStr := NativeChainString(id); nativeStr != "" {
 		return nativeStr
 	}
+	if c := globalStringCache.Load(); c != nil {
+		return c.ID(id)
+	}
 
-	// We assume that the maximum size of a byte slice that
-	// can be stringified is at least the length of an ID
-	s, _ := cb58.Encode(id[:])
-	return s
+	var buf [cb58MaxLen]byte
+	return string(appendCB58(buf[:0], id[:]))
+}
+
+// AppendString appends the String form of this id to [dst] without
+// allocating, provided [dst] has room for it. The output is identical to
+// String.
+func (id ID) AppendString(dst []byte) []byte {
+	if nativeStr := NativeChainString(id); nativeStr != "" {
+		return append(dst, nativeStr...)
+	}
+	return appendCB58(dst, id[:])
+}
+
+// PrefixedString returns the String representation with a prefix added
+func (id ID) PrefixedString(prefix string) string {
+	return prefix + id.String()
 }
 
 func (id ID) MarshalText() ([]byte, error) {
-	return []byte(id.String()), nil
+	return id.AppendString(make([]byte, 0, cb58MaxLen)), nil
+}
+
+// AppendText appends the String form of this id to [b].
+func (id ID) AppendText(b []byte) ([]byte, error) {
+	return id.AppendString(b), nil
+}
+
+// MarshalBinary returns the raw 32 bytes of this id.
+func (id ID) MarshalBinary() ([]byte, error) {
+	return id.AppendBinary(make([]byte, 0, IDLen))
+}
+
+// AppendBinary appends the raw 32 bytes of this id to [b].
+func (id ID) AppendBinary(b []byte) ([]byte, error) {
+	return append(b, id[:]...), nil
+}
+
+// UnmarshalBinary is the inverse of MarshalBinary. The input must be exactly
+// IDLen bytes.
+func (id *ID) UnmarshalBinary(b []byte) error {
+	parsed, err := ToID(b)
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
 }
 
 func (id ID) Compare(other ID) int {
@@ -0,0 +1,229 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package idstest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/ids"
+)
+
+// An AliasEditorTest couples a test in the AliaserEditor suite with a
+// human-readable name.
+type AliasEditorTest struct {
+	Name string
+	Test func(testing.TB, ids.AliaserReader, ids.AliaserEditor)
+}
+
+// Run runs the test on the AliaserReader and AliaserEditor pair.
+func (tt *AliasEditorTest) Run(t *testing.T, r ids.AliaserReader, e ids.AliaserEditor) {
+	t.Run(tt.Name, func(t *testing.T) {
+		tt.Test(t, r, e)
+	})
+}
+
+// RunAllAliasEditor runs all [AliasEditorTests], constructing a new pair
+// for each. AliaserEditor implementations should pass [AliasTests] too.
+func RunAllAliasEditor(t *testing.T, ctor func() (ids.AliaserReader, ids.AliaserEditor)) {
+	for _, tt := range AliasEditorTests {
+		r, e := ctor()
+		tt.Run(t, r, e)
+	}
+}
+
+var AliasEditorTests = []AliasEditorTest{
+	{"Remove Single Alias", TestAliaserRemoveSingleAlias},
+	{"Remove Primary Alias", TestAliaserRemovePrimaryAlias},
+	{"Remove Unknown Alias", TestAliaserRemoveUnknownAlias},
+	{"Set Primary Alias", TestAliaserSetPrimaryAlias},
+	{"Set Primary Alias Not Of ID", TestAliaserSetPrimaryAliasNotOfID},
+	{"Replace Aliases", TestAliaserReplaceAliases},
+	{"Replace Aliases Empty", TestAliaserReplaceAliasesEmpty},
+	{"Replace Aliases Clash", TestAliaserReplaceAliasesClash},
+	{"Replace Aliases Duplicate", TestAliaserReplaceAliasesDuplicate},
+}
+
+func TestAliaserRemoveSingleAlias(tb testing.TB, r ids.AliaserReader, e ids.AliaserEditor) {
+	require := require.New(tb)
+	id1 := ids.ID{'B', 'r', 'u', 'c', 'e', ' ', 'W', 'a', 'y', 'n', 'e'}
+	id2 := ids.ID{'D', 'i', 'c', 'k', ' ', 'G', 'r', 'a', 'y', 's', 'o', 'n'}
+
+	require.NoError(e.Alias(id1, "Batman"))
+	require.NoError(e.Alias(id1, "Dark Knight"))
+	require.NoError(e.Alias(id1, "Caped Crusader"))
+
+	require.NoError(e.RemoveAlias("Dark Knight"))
+
+	_, err := r.Lookup("Dark Knight")
+	require.ErrorIs(err, ids.ErrNoIDWithAlias)
+	aliases, err := r.Aliases(id1)
+	require.NoError(err)
+	require.Equal([]string{"Batman", "Caped Crusader"}, aliases)
+
+	// The removed alias is free again.
+	require.NoError(e.Alias(id2, "Dark Knight"))
+	res, err := r.Lookup("Dark Knight")
+	require.NoError(err)
+	require.Equal(id2, res)
+}
+
+func TestAliaserRemovePrimaryAlias(tb testing.TB, r ids.AliaserReader, e ids.AliaserEditor) {
+	require := require.New(tb)
+	id := ids.ID{'B', 'r', 'u', 'c', 'e', ' ', 'W', 'a', 'y', 'n', 'e'}
+
+	require.NoError(e.Alias(id, "Batman"))
+	require.NoError(e.Alias(id, "Dark Knight"))
+
+	require.NoError(e.RemoveAlias("Batman"))
+	res, err := r.PrimaryAlias(id)
+	require.NoError(err)
+	require.Equal("Dark Knight", res)
+
+	require.NoError(e.RemoveAlias("Dark Knight"))
+	_, err = r.PrimaryAlias(id)
+	require.ErrorIs(err, ids.ErrNoAliasForID)
+}
+
+func TestAliaserRemoveUnknownAlias(tb testing.TB, r ids.AliaserReader, e ids.AliaserEditor) {
+	require := require.New(tb)
+	id := ids.ID{'B', 'r', 'u', 'c', 'e', ' ', 'W', 'a', 'y', 'n', 'e'}
+
+	require.NoError(e.Alias(id, "Batman"))
+
+	err := e.RemoveAlias("Robin")
+	require.ErrorIs(err, ids.ErrNoIDWithAlias)
+
+	aliases, err := r.Aliases(id)
+	require.NoError(err)
+	require.Equal([]string{"Batman"}, aliases)
+}
+
+func TestAliaserSetPrimaryAlias(tb testing.TB, r ids.AliaserReader, e ids.AliaserEditor) {
+	require := require.New(tb)
+	id := ids.ID{'B', 'r', 'u', 'c', 'e', ' ', 'W', 'a', 'y', 'n', 'e'}
+
+	require.NoError(e.Alias(id, "Batman"))
+	require.NoError(e.Alias(id, "Dark Knight"))
+	require.NoError(e.Alias(id, "Caped Crusader"))
+
+	require.NoError(e.SetPrimaryAlias(id, "Caped Crusader"))
+	res, err := r.PrimaryAlias(id)
+	require.NoError(err)
+	require.Equal("Caped Crusader", res)
+	aliases, err := r.Aliases(id)
+	require.NoError(err)
+	require.Equal([]string{"Caped Crusader", "Batman", "Dark Knight"}, aliases)
+
+	// Setting the current primary alias is a no-op.
+	require.NoError(e.SetPrimaryAlias(id, "Caped Crusader"))
+	aliases, err = r.Aliases(id)
+	require.NoError(err)
+	require.Equal([]string{"Caped Crusader", "Batman", "Dark Knight"}, aliases)
+
+	owner, err := r.Lookup("Batman")
+	require.NoError(err)
+	require.Equal(id, owner)
+}
+
+func TestAliaserSetPrimaryAliasNotOfID(tb testing.TB, r ids.AliaserReader, e ids.AliaserEditor) {
+	require := require.New(tb)
+	id1 := ids.ID{'B', 'r', 'u', 'c', 'e', ' ', 'W', 'a', 'y', 'n', 'e'}
+	id2 := ids.ID{'D', 'i', 'c', 'k', ' ', 'G', 'r', 'a', 'y', 's', 'o', 'n'}
+
+	require.NoError(e.Alias(id1, "Batman"))
+	require.NoError(e.Alias(id2, "Robin"))
+
+	err := e.SetPrimaryAlias(id1, "Robin")
+	require.ErrorIs(err, ids.ErrAliasNotOfID)
+	err = e.SetPrimaryAlias(id1, "Alfred")
+	require.ErrorIs(err, ids.ErrAliasNotOfID)
+
+	res, err := r.PrimaryAlias(id1)
+	require.NoError(err)
+	require.Equal("Batman", res)
+	res, err = r.PrimaryAlias(id2)
+	require.NoError(err)
+	require.Equal("Robin", res)
+}
+
+func TestAliaserReplaceAliases(tb testing.TB, r ids.AliaserReader, e ids.AliaserEditor) {
+	require := require.New(tb)
+	id := ids.ID{'B', 'r', 'u', 'c', 'e', ' ', 'W', 'a', 'y', 'n', 'e'}
+
+	require.NoError(e.Alias(id, "Batman"))
+	require.NoError(e.Alias(id, "Dark Knight"))
+
+	require.NoError(e.ReplaceAliases(id, []string{"Caped Crusader", "Batman"}))
+	aliases, err := r.Aliases(id)
+	require.NoError(err)
+	require.Equal([]string{"Caped Crusader", "Batman"}, aliases)
+
+	for _, alias := range []string{"Caped Crusader", "Batman"} {
+		res, err := r.Lookup(alias)
+		require.NoError(err)
+		require.Equal(id, res)
+	}
+	_, err = r.Lookup("Dark Knight")
+	require.ErrorIs(err, ids.ErrNoIDWithAlias)
+
+	// An ID without aliases can be given some.
+	id2 := ids.ID{'D', 'i', 'c', 'k', ' ', 'G', 'r', 'a', 'y', 's', 'o', 'n'}
+	require.NoError(e.ReplaceAliases(id2, []string{"Robin", "Dark Knight"}))
+	res, err := r.PrimaryAlias(id2)
+	require.NoError(err)
+	require.Equal("Robin", res)
+}
+
+func TestAliaserReplaceAliasesEmpty(tb testing.TB, r ids.AliaserReader, e ids.AliaserEditor) {
+	require := require.New(tb)
+	id := ids.ID{'B', 'r', 'u', 'c', 'e', ' ', 'W', 'a', 'y', 'n', 'e'}
+
+	require.NoError(e.Alias(id, "Batman"))
+	require.NoError(e.Alias(id, "Dark Knight"))
+
+	require.NoError(e.ReplaceAliases(id, nil))
+	aliases, err := r.Aliases(id)
+	require.NoError(err)
+	require.Empty(aliases)
+	_, err = r.Lookup("Batman")
+	require.ErrorIs(err, ids.ErrNoIDWithAlias)
+}
+
+func TestAliaserReplaceAliasesClash(tb testing.TB, r ids.AliaserReader, e ids.AliaserEditor) {
+	require := require.New(tb)
+	id1 := ids.ID{'B', 'r', 'u', 'c', 'e', ' ', 'W', 'a', 'y', 'n', 'e'}
+	id2 := ids.ID{'D', 'i', 'c', 'k', ' ', 'G', 'r', 'a', 'y', 's', 'o', 'n'}
+
+	require.NoError(e.Alias(id1, "Batman"))
+	require.NoError(e.Alias(id2, "Robin"))
+
+	err := e.ReplaceAliases(id1, []string{"Dark Knight", "Robin"})
+	require.ErrorIs(err, ids.ErrAliasAlreadyMapped)
+
+	// Nothing was applied.
+	aliases, err := r.Aliases(id1)
+	require.NoError(err)
+	require.Equal([]string{"Batman"}, aliases)
+	_, err = r.Lookup("Dark Knight")
+	require.ErrorIs(err, ids.ErrNoIDWithAlias)
+	res, err := r.Lookup("Robin")
+	require.NoError(err)
+	require.Equal(id2, res)
+}
+
+func TestAliaserReplaceAliasesDuplicate(tb testing.TB, r ids.AliaserReader, e ids.AliaserEditor) {
+	require := require.New(tb)
+	id := ids.ID{'B', 'r', 'u', 'c', 'e', ' ', 'W', 'a', 'y', 'n', 'e'}
+
+	require.NoError(e.Alias(id, "Batman"))
+
+	err := e.ReplaceAliases(id, []string{"Dark Knight", "Dark Knight"})
+	require.ErrorIs(err, ids.ErrAliasAlreadyMapped)
+
+	aliases, err := r.Aliases(id)
+	require.NoError(err)
+	require.Equal([]string{"Batman"}, aliases)
+}
@@ -93,7 +93,7 @@ func TestAliaserPrimaryAlias(tb testing.TB, r ids.AliaserReader, w ids.AliaserWr
 	require.NoError(w.Alias(id2, "Dark Knight"))
 
 	_, err := r.PrimaryAlias(id1)
-	// TODO: require error to be errNoAliasForID
+	// TODO: require error to be ErrNoAliasForID
 	require.Error(err) //nolint:forbidigo // currently returns grpc errors too
 
 	expected := "Batman"
@@ -110,7 +110,7 @@ func TestAliaserAliasClash(tb testing.TB, _ ids.AliaserReader, w ids.AliaserWrit
 	require.NoError(w.Alias(id1, "Batman"))
 
 	err := w.Alias(id2, "Batman")
-	// TODO: require error to be errAliasAlreadyMapped
+	// TODO: require error to be ErrAliasAlreadyMapped
 	require.Error(err) //nolint:forbidigo // currently returns grpc errors too
 }
 
@@ -125,7 +125,7 @@ func TestAliaserRemoveAlias(tb testing.TB, r ids.AliaserReader, w ids.AliaserWri
 	w.RemoveAliases(id1)
 
 	_, err := r.PrimaryAlias(id1)
-	// TODO: require error to be errNoAliasForID
+	// TODO: require error to be ErrNoAliasForID
 	require.Error(err) //nolint:forbidigo // currently returns grpc errors too
 
 	require.NoError(w.Alias(id2, "Batman"))
@@ -0,0 +1,262 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"slices"
+	"sync"
+)
+
+// NodeIDBits is the number of bits in a NodeID.
+const NodeIDBits = NodeIDLen * BitsPerByte
+
+var errInvalidBucketSize = errors.New("bucket size must be positive")
+
+// Distance returns the Kademlia XOR distance between [a] and [b].
+//
+// The distance is read as a 160-bit big-endian unsigned integer: byte 0 is
+// the most significant and, within a byte, bit 7 is the most significant.
+// This is the numeric order Kademlia requires and is deliberately not the
+// LSB-first indexing of EqualSubset and ID.Bit.
+func Distance(a, b NodeID) NodeID {
+	for i := range a {
+		a[i] ^= b[i]
+	}
+	return a
+}
+
+// BucketIndex returns the number of leading zero bits of the distance
+// between [a] and [b], i.e. the length of the prefix they share when read
+// most significant bit first. Identical IDs return NodeIDBits.
+func BucketIndex(a, b NodeID) int {
+	for i := range a {
+		if x := a[i] ^ b[i]; x != 0 {
+			return i*BitsPerByte + bits.LeadingZeros8(x)
+		}
+	}
+	return NodeIDBits
+}
+
+// CompareDistance returns -1, 0 or 1 if [a] is respectively closer to, as
+// close to, or further from [target] than [b]. Since XOR is a bijection,
+// distinct IDs are never equally close.
+func CompareDistance(a, b, target NodeID) int {
+	for i := range target {
+		da := a[i] ^ target[i]
+		db := b[i] ^ target[i]
+		if da != db {
+			if da < db {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// Closer reports whether [a] is strictly closer to [target] than [b].
+func Closer(a, b, target NodeID) bool {
+	return CompareDistance(a, b, target) < 0
+}
+
+// RoutingTableConfig configures a RoutingTable.
+type RoutingTableConfig struct {
+	// K is the maximum number of nodes held in each bucket.
+	K int
+
+	// ShouldEvict is consulted when [candidate] would be added to a full
+	// bucket that cannot be split. [lru] is the least-recently-seen node in
+	// that bucket; returning true replaces it with [candidate]. If nil, the
+	// candidate is dropped, which is Kademlia's preference for long-lived
+	// nodes.
+	//
+	// The hook is called with the table locked. It must not block or call
+	// back into the table; a policy that wants to ping [lru] first should
+	// return false, ping asynchronously, and Remove [lru] and Add
+	// [candidate] once the ping fails.
+	ShouldEvict func(lru, candidate NodeID) bool
+
+	// OnEvict, if non-nil, is called with the table locked after ShouldEvict
+	// caused [evicted] to be removed.
+	OnEvict func(evicted NodeID)
+}
+
+// RoutingTable is a Kademlia k-bucket routing table centred on a local
+// NodeID. It is safe for concurrent use.
+//
+// Buckets are split lazily: the table starts with a single bucket and,
+// whenever the bucket covering the local NodeID overflows, nodes that share
+// a longer prefix with the local NodeID are moved into a new bucket. Within
+// a bucket, nodes are ordered from least to most recently seen.
+type RoutingTable struct {
+	self   NodeID
+	config RoutingTableConfig
+
+	lock sync.RWMutex
+	// buckets[i] holds the nodes whose BucketIndex with self is i, except
+	// for the last bucket, which holds every node whose index is at least
+	// len(buckets)-1.
+	buckets [][]NodeID
+	size    int
+}
+
+// NewRoutingTable returns an empty routing table for the node [self].
+func NewRoutingTable(self NodeID, config RoutingTableConfig) (*RoutingTable, error) {
+	if config.K <= 0 {
+		return nil, fmt.Errorf("%w: %d", errInvalidBucketSize, config.K)
+	}
+	return &RoutingTable{
+		self:    self,
+		config:  config,
+		buckets: make([][]NodeID, 1),
+	}, nil
+}
+
+// Self returns the NodeID the table is centred on.
+func (rt *RoutingTable) Self() NodeID {
+	return rt.self
+}
+
+// Len returns the number of nodes in the table.
+func (rt *RoutingTable) Len() int {
+	rt.lock.RLock()
+	defer rt.lock.RUnlock()
+
+	return rt.size
+}
+
+// NumBuckets returns the number of buckets the table has split into.
+func (rt *RoutingTable) NumBuckets() int {
+	rt.lock.RLock()
+	defer rt.lock.RUnlock()
+
+	return len(rt.buckets)
+}
+
+// Bucket returns a copy of bucket [i], ordered from least to most recently
+// seen. Out of range indices return nil.
+func (rt *RoutingTable) Bucket(i int) []NodeID {
+	rt.lock.RLock()
+	defer rt.lock.RUnlock()
+
+	if i < 0 || i >= len(rt.buckets) {
+		return nil
+	}
+	return slices.Clone(rt.buckets[i])
+}
+
+// Contains returns true if [id] is in the table.
+func (rt *RoutingTable) Contains(id NodeID) bool {
+	rt.lock.RLock()
+	defer rt.lock.RUnlock()
+
+	return slices.Contains(rt.buckets[rt.bucketFor(id)], id)
+}
+
+// Add records that [id] was seen. A node already in the table becomes the
+// most recently seen node of its bucket. Returns true if [id] is in the
+// table afterwards; the local NodeID is never added.
+func (rt *RoutingTable) Add(id NodeID) bool {
+	if id == rt.self {
+		return false
+	}
+
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	for {
+		i := rt.bucketFor(id)
+		bucket := rt.buckets[i]
+		if pos := slices.Index(bucket, id); pos >= 0 {
+			copy(bucket[pos:], bucket[pos+1:])
+			bucket[len(bucket)-1] = id
+			return true
+		}
+
+		if len(bucket) < rt.config.K {
+			rt.buckets[i] = append(bucket, id)
+			rt.size++
+			return true
+		}
+
+		if i == len(rt.buckets)-1 && len(rt.buckets) < NodeIDBits {
+			rt.split()
+			continue
+		}
+
+		lru := bucket[0]
+		if rt.config.ShouldEvict == nil || !rt.config.ShouldEvict(lru, id) {
+			return false
+		}
+		copy(bucket, bucket[1:])
+		bucket[len(bucket)-1] = id
+		if rt.config.OnEvict != nil {
+			rt.config.OnEvict(lru)
+		}
+		return true
+	}
+}
+
+// Remove deletes [id] from the table, returning true if it was present.
+func (rt *RoutingTable) Remove(id NodeID) bool {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	i := rt.bucketFor(id)
+	pos := slices.Index(rt.buckets[i], id)
+	if pos < 0 {
+		return false
+	}
+	rt.buckets[i] = slices.Delete(rt.buckets[i], pos, pos+1)
+	rt.size--
+	return true
+}
+
+// ClosestN returns up to [n] nodes in the table ordered by increasing
+// distance to [target]. The result is deterministic for a given table
+// content.
+func (rt *RoutingTable) ClosestN(target NodeID, n int) []NodeID {
+	rt.lock.RLock()
+	defer rt.lock.RUnlock()
+
+	if n <= 0 {
+		return nil
+	}
+
+	nodes := make([]NodeID, 0, rt.size)
+	for _, bucket := range rt.buckets {
+		nodes = append(nodes, bucket...)
+	}
+	slices.SortFunc(nodes, func(a, b NodeID) int {
+		return CompareDistance(a, b, target)
+	})
+	if len(nodes) > n {
+		nodes = nodes[:n]
+	}
+	return nodes
+}
+
+// bucketFor returns the index of the bucket that holds, or would hold, [id].
+func (rt *RoutingTable) bucketFor(id NodeID) int {
+	return min(BucketIndex(rt.self, id), len(rt.buckets)-1)
+}
+
+// split moves the nodes of the last bucket that share a longer prefix with
+// self into a new last bucket. The relative recency order is preserved.
+func (rt *RoutingTable) split() {
+	last := len(rt.buckets) - 1
+	var kept, moved []NodeID
+	for _, id := range rt.buckets[last] {
+		if BucketIndex(rt.self, id) > last {
+			moved = append(moved, id)
+		} else {
+			kept = append(kept, id)
+		}
+	}
+	rt.buckets[last] = kept
+	rt.buckets = append(rt.buckets, moved)
+}
@@ -0,0 +1,158 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistance(t *testing.T) {
+	require := require.New(t)
+
+	a := NodeID{0xf0, 0x01}
+	b := NodeID{0x0f, 0x01}
+	require.Equal(NodeID{0xff}, Distance(a, b))
+	require.Equal(EmptyNodeID, Distance(a, a))
+	require.Equal(NodeID{0xf0, 0x01}, a, "inputs must not be modified")
+}
+
+func TestBucketIndex(t *testing.T) {
+	tests := []struct {
+		a, b     NodeID
+		expected int
+	}{
+		{NodeID{}, NodeID{}, NodeIDBits},
+		{NodeID{0x80}, NodeID{}, 0},
+		{NodeID{0x01}, NodeID{}, 7},
+		{NodeID{0x00, 0x40}, NodeID{}, 9},
+		{NodeID{19: 0x01}, NodeID{}, NodeIDBits - 1},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, BucketIndex(tt.a, tt.b))
+		require.Equal(t, tt.expected, BucketIndex(tt.b, tt.a))
+	}
+}
+
+func TestCloser(t *testing.T) {
+	require := require.New(t)
+
+	target := NodeID{0x10}
+	near := NodeID{0x11} // distance 0x01...
+	far := NodeID{0x90}  // distance 0x80...
+
+	require.True(Closer(near, far, target))
+	require.False(Closer(far, near, target))
+	require.False(Closer(near, near, target))
+	require.Equal(-1, CompareDistance(near, far, target))
+	require.Equal(1, CompareDistance(far, near, target))
+	require.Zero(CompareDistance(near, near, target))
+}
+
+func TestRoutingTableInvalidK(t *testing.T) {
+	_, err := NewRoutingTable(EmptyNodeID, RoutingTableConfig{})
+	require.ErrorIs(t, err, errInvalidBucketSize)
+}
+
+func TestRoutingTableSplitsAndLRU(t *testing.T) {
+	require := require.New(t)
+
+	self := NodeID{}
+	rt, err := NewRoutingTable(self, RoutingTableConfig{K: 2})
+	require.NoError(err)
+
+	require.False(rt.Add(self))
+
+	far1 := NodeID{0x80, 0x01}
+	far2 := NodeID{0x80, 0x02}
+	far3 := NodeID{0x80, 0x03}
+	near := NodeID{0x01}
+
+	require.True(rt.Add(far1))
+	require.True(rt.Add(far2))
+	require.Equal(1, rt.NumBuckets())
+
+	// The only bucket covers self, so it splits instead of dropping.
+	require.True(rt.Add(near))
+	require.Greater(rt.NumBuckets(), 1)
+	require.Equal([]NodeID{far1, far2}, rt.Bucket(0))
+	require.True(rt.Contains(near))
+
+	// Bucket 0 no longer covers self; without a policy the newcomer is
+	// dropped.
+	require.False(rt.Add(far3))
+	require.False(rt.Contains(far3))
+
+	// Re-adding marks the node as most recently seen.
+	require.True(rt.Add(far1))
+	require.Equal([]NodeID{far2, far1}, rt.Bucket(0))
+	require.Equal(3, rt.Len())
+
+	require.True(rt.Remove(far2))
+	require.False(rt.Remove(far2))
+	require.True(rt.Add(far3))
+	require.Equal([]NodeID{far1, far3}, rt.Bucket(0))
+}
+
+func TestRoutingTableEvictionPolicy(t *testing.T) {
+	require := require.New(t)
+
+	var evicted []NodeID
+	rt, err := NewRoutingTable(NodeID{}, RoutingTableConfig{
+		K: 1,
+		ShouldEvict: func(lru, candidate NodeID) bool {
+			return candidate[1] > lru[1]
+		},
+		OnEvict: func(id NodeID) {
+			evicted = append(evicted, id)
+		},
+	})
+	require.NoError(err)
+
+	require.True(rt.Add(NodeID{0x01})) // forces the first bucket to split later
+	require.True(rt.Add(NodeID{0x80, 0x02}))
+	require.False(rt.Add(NodeID{0x80, 0x01}))
+	require.True(rt.Add(NodeID{0x80, 0x03}))
+	require.Equal([]NodeID{{0x80, 0x02}}, evicted)
+	require.Equal([]NodeID{{0x80, 0x03}}, rt.Bucket(0))
+}
+
+func TestRoutingTableClosestN(t *testing.T) {
+	require := require.New(t)
+
+	rng := rand.New(rand.NewSource(1)) //#nosec G404
+	randomNodeID := func() NodeID {
+		var id NodeID
+		_, _ = rng.Read(id[:])
+		return id
+	}
+
+	self := randomNodeID()
+	rt, err := NewRoutingTable(self, RoutingTableConfig{K: 20})
+	require.NoError(err)
+
+	var all []NodeID
+	for i := 0; i < 500; i++ {
+		id := randomNodeID()
+		if rt.Add(id) {
+			all = append(all, id)
+		}
+	}
+	require.Equal(len(all), rt.Len())
+
+	for i := 0; i < 20; i++ {
+		target := randomNodeID()
+		expected := slices.Clone(all)
+		slices.SortFunc(expected, func(a, b NodeID) int {
+			return CompareDistance(a, b, target)
+		})
+		require.Equal(expected[:16], rt.ClosestN(target, 16))
+	}
+
+	require.Nil(rt.ClosestN(self, 0))
+	require.Len(rt.ClosestN(self, len(all)+10), len(all))
+}
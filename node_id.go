@@ -27,7 +27,18 @@ var (
 type NodeID ShortID
 
 func (id NodeID) String() string {
-	return ShortID(id).PrefixedString(NodeIDPrefix)
+	if c := globalStringCache.Load(); c != nil {
+		return c.NodeID(id)
+	}
+
+	var buf [len(NodeIDPrefix) + cb58MaxLen]byte
+	return string(id.AppendString(buf[:0]))
+}
+
+// AppendString appends the String form of this id, "NodeID-" prefix
+// included, to [dst] without allocating, provided [dst] has room for it.
+func (id NodeID) AppendString(dst []byte) []byte {
+	return appendCB58(append(dst, NodeIDPrefix...), id[:])
 }
 
 func (id NodeID) Bytes() []byte {
@@ -35,11 +46,40 @@ func (id NodeID) Bytes() []byte {
 }
 
 func (id NodeID) MarshalJSON() ([]byte, error) {
-	return []byte(`"` + id.String() + `"`), nil
+	b := make([]byte, 0, len(NodeIDPrefix)+cb58MaxLen+2)
+	b = append(b, '"')
+	b = id.AppendString(b)
+	return append(b, '"'), nil
 }
 
 func (id NodeID) MarshalText() ([]byte, error) {
-	return []byte(id.String()), nil
+	return id.AppendString(make([]byte, 0, len(NodeIDPrefix)+cb58MaxLen)), nil
+}
+
+// AppendText appends the String form of this id to [b].
+func (id NodeID) AppendText(b []byte) ([]byte, error) {
+	return id.AppendString(b), nil
+}
+
+// MarshalBinary returns the raw 20 bytes of this id.
+func (id NodeID) MarshalBinary() ([]byte, error) {
+	return id.AppendBinary(make([]byte, 0, NodeIDLen))
+}
+
+// AppendBinary appends the raw 20 bytes of this id to [b].
+func (id NodeID) AppendBinary(b []byte) ([]byte, error) {
+	return append(b, id[:]...), nil
+}
+
+// UnmarshalBinary is the inverse of MarshalBinary. The input must be exactly
+// NodeIDLen bytes.
+func (id *NodeID) UnmarshalBinary(b []byte) error {
+	parsed, err := ToNodeID(b)
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
 }
 
 func (id *NodeID) UnmarshalJSON(b []byte) error {
@@ -60,8 +100,18 @@ func (id *NodeID) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// UnmarshalText decodes a "NodeID-<cb58>" string. It is the inverse of
+// MarshalText, so unlike UnmarshalJSON it accepts unquoted input, which is
+// how the stdlib passes map keys to a TextUnmarshaler. Quoted input, "null"
+// and empty input are handled as UnmarshalJSON handles them.
 func (id *NodeID) UnmarshalText(text []byte) error {
-	return id.UnmarshalJSON(text)
+	str := string(text)
+	if str == "" || str == nullStr || str[0] == '"' {
+		return id.UnmarshalJSON(text)
+	}
+	var err error
+	*id, err = NodeIDFromString(str)
+	return err
 }
 
 func (id NodeID) Compare(other NodeID) int {
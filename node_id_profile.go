@@ -0,0 +1,199 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// node_id_profile.go — the chain-side NodeIDScheme gate.
+//
+// The scheme byte on a TypedNodeID says what kind of key produced it; a
+// NodeIDProfile says what a chain is willing to accept. Every boundary that
+// admits a NodeID (peer handshake, validator registration, proposer
+// attribution) runs the presented TypedNodeID through Check or CheckWire
+// so the downgrade gate is implemented once, here.
+
+package ids
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+)
+
+// ClassicalCompatUnsafeEnv is the environment variable an operator sets to
+// "true" to let a post-quantum chain accept classical NodeIDs. See
+// NodeIDProfileFromEnv.
+const ClassicalCompatUnsafeEnv = "LUX_CLASSICAL_COMPAT_UNSAFE"
+
+// ErrClassicalCompatDisabled — a classical profile was requested without
+// the LUX_CLASSICAL_COMPAT_UNSAFE opt-in.
+var ErrClassicalCompatDisabled = errors.New("ids: classical NodeIDs require " + ClassicalCompatUnsafeEnv)
+
+// NodeIDProfileKind is the security posture of a NodeIDProfile.
+type NodeIDProfileKind uint8
+
+const (
+	// NodeIDProfileInvalid is the zero value. A profile of this kind
+	// rejects every NodeID.
+	NodeIDProfileInvalid NodeIDProfileKind = iota
+
+	// NodeIDProfileStrictPQ accepts only the post-quantum schemes in the
+	// profile's allowed set.
+	NodeIDProfileStrictPQ
+
+	// NodeIDProfilePQClassicalCompat accepts the allowed post-quantum
+	// schemes and, in addition, every classical-compat scheme. This is the
+	// LUX_CLASSICAL_COMPAT_UNSAFE posture.
+	NodeIDProfilePQClassicalCompat
+
+	// NodeIDProfileClassical is a chain that has not migrated to
+	// post-quantum NodeIDs. Its pinned scheme is classical.
+	NodeIDProfileClassical
+)
+
+// String returns a short name for this kind.
+func (k NodeIDProfileKind) String() string {
+	switch k {
+	case NodeIDProfileStrictPQ:
+		return "strict-pq"
+	case NodeIDProfilePQClassicalCompat:
+		return "pq-classical-compat-unsafe"
+	case NodeIDProfileClassical:
+		return "classical"
+	default:
+		return fmt.Sprintf("node-id-profile(%d)", uint8(k))
+	}
+}
+
+// NodeIDProfile is the NodeIDScheme policy of one chain: the scheme the
+// chain pins for its validators and the set of schemes it accepts from
+// peers. A NodeIDProfile is immutable; the zero value rejects everything.
+type NodeIDProfile struct {
+	kind    NodeIDProfileKind
+	pinned  NodeIDScheme
+	allowed Set[NodeIDScheme]
+}
+
+// NewNodeIDProfile returns a profile of [kind] pinned to [pinned]. The
+// pinned scheme is always allowed; [allowed] adds further schemes, e.g. an
+// SLH-DSA scheme for governance validators on an ML-DSA chain.
+//
+// Strict-PQ and PQ-classical-compat profiles must pin, and may only
+// additionally allow, post-quantum schemes. A classical profile must pin a
+// classical-compat scheme and may allow post-quantum schemes while its
+// validators migrate. Every scheme must be registered and not retired.
+func NewNodeIDProfile(kind NodeIDProfileKind, pinned NodeIDScheme, allowed ...NodeIDScheme) (NodeIDProfile, error) {
+	var wantClass NodeIDSchemeClass
+	switch kind {
+	case NodeIDProfileStrictPQ, NodeIDProfilePQClassicalCompat:
+		wantClass = NodeIDSchemeClassPostQuantum
+	case NodeIDProfileClassical:
+		wantClass = NodeIDSchemeClassClassicalCompatUnsafe
+	default:
+		return NodeIDProfile{}, fmt.Errorf("%w: profile kind %s", ErrNodeIDSchemeInvalid, kind)
+	}
+
+	set := NewSet[NodeIDScheme](len(allowed) + 1)
+	for i, s := range append([]NodeIDScheme{pinned}, allowed...) {
+		if err := s.checkKnown(); err != nil {
+			return NodeIDProfile{}, err
+		}
+		d, _ := nodeIDSchemes.active(s)
+		if (i == 0 || wantClass == NodeIDSchemeClassPostQuantum) && d.Class != wantClass {
+			return NodeIDProfile{}, fmt.Errorf("%w: %s profile cannot allow %s scheme %s",
+				ErrNodeIDSchemeInvalid, kind, d.Class, s)
+		}
+		set.Add(s)
+	}
+	return NodeIDProfile{
+		kind:    kind,
+		pinned:  pinned,
+		allowed: set,
+	}, nil
+}
+
+// NodeIDProfileFromEnv returns the profile for a chain pinned to [pinned],
+// reading the LUX_CLASSICAL_COMPAT_UNSAFE opt-in from the environment.
+//
+// For a post-quantum [pinned] scheme, the result is strict-PQ unless the
+// variable parses as true, in which case it is PQ-classical-compat. For a
+// classical [pinned] scheme, the variable must parse as true; otherwise
+// ErrClassicalCompatDisabled is returned. A value that does not parse as a
+// boolean is an error rather than being read as false, so a typo cannot
+// silently change the posture.
+func NodeIDProfileFromEnv(pinned NodeIDScheme, allowed ...NodeIDScheme) (NodeIDProfile, error) {
+	compat := false
+	if v, ok := os.LookupEnv(ClassicalCompatUnsafeEnv); ok {
+		var err error
+		compat, err = strconv.ParseBool(v)
+		if err != nil {
+			return NodeIDProfile{}, fmt.Errorf("parsing %s=%q: %w", ClassicalCompatUnsafeEnv, v, err)
+		}
+	}
+
+	switch {
+	case pinned.IsClassicalCompatUnsafe() && !compat:
+		return NodeIDProfile{}, fmt.Errorf("%w: pinned scheme %s", ErrClassicalCompatDisabled, pinned)
+	case pinned.IsClassicalCompatUnsafe():
+		return NewNodeIDProfile(NodeIDProfileClassical, pinned, allowed...)
+	case compat:
+		return NewNodeIDProfile(NodeIDProfilePQClassicalCompat, pinned, allowed...)
+	default:
+		return NewNodeIDProfile(NodeIDProfileStrictPQ, pinned, allowed...)
+	}
+}
+
+// Kind returns the posture of this profile.
+func (p NodeIDProfile) Kind() NodeIDProfileKind {
+	return p.kind
+}
+
+// Pinned returns the scheme this profile pins for its validators.
+func (p NodeIDProfile) Pinned() NodeIDScheme {
+	return p.pinned
+}
+
+// Allowed returns the explicitly allowed schemes, pinned scheme included,
+// in byte order. A PQ-classical-compat profile additionally accepts every
+// classical-compat scheme.
+func (p NodeIDProfile) Allowed() []NodeIDScheme {
+	schemes := p.allowed.List()
+	slices.Sort(schemes)
+	return schemes
+}
+
+// Check is the cross-axis gate. It returns nil if this profile accepts
+// [t]'s scheme, ErrNodeIDSchemeInvalid or ErrNodeIDSchemeUnknown if the
+// scheme is not a registered, live scheme, and ErrNodeIDSchemeMismatch if
+// the scheme is known but not accepted by this profile.
+func (p NodeIDProfile) Check(t TypedNodeID) error {
+	if err := t.Scheme.checkKnown(); err != nil {
+		return err
+	}
+	if p.allowed.Contains(t.Scheme) {
+		return nil
+	}
+	if p.kind == NodeIDProfilePQClassicalCompat && t.Scheme.IsClassicalCompatUnsafe() {
+		return nil
+	}
+	return fmt.Errorf("%w: presented=%s pinned=%s profile=%s",
+		ErrNodeIDSchemeMismatch, t.Scheme, p.pinned, p.kind)
+}
+
+// CheckWire parses the 21-byte wire form with ParseTypedNodeID and runs
+// Check on the result, returning the same errors as Check. The parsed
+// TypedNodeID is returned only if it is accepted.
+func (p NodeIDProfile) CheckWire(b []byte) (TypedNodeID, error) {
+	t, err := ParseTypedNodeID(b)
+	if errors.Is(err, ErrNodeIDSchemeUnknown) && NodeIDScheme(b[0]) == NodeIDSchemeInvalid {
+		// The wire decoder reports 0x00 as an unknown byte; Check reports
+		// it as invalid.
+		return TypedNodeID{}, NodeIDSchemeInvalid.checkKnown()
+	}
+	if err != nil {
+		return TypedNodeID{}, err
+	}
+	if err := p.Check(t); err != nil {
+		return TypedNodeID{}, err
+	}
+	return t, nil
+}
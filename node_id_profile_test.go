@@ -0,0 +1,208 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeIDProfile_Check(t *testing.T) {
+	mldsa65 := TypedNodeID{Scheme: NodeIDSchemeMLDSA65, NodeID: NodeID{1}}
+	mldsa87 := TypedNodeID{Scheme: NodeIDSchemeMLDSA87, NodeID: NodeID{2}}
+	slhdsa := TypedNodeID{Scheme: NodeIDSchemeSLHDSASHAKE256s, NodeID: NodeID{3}}
+	secp := TypedNodeID{Scheme: NodeIDSchemeSecp256k1, NodeID: NodeID{4}}
+	unknown := TypedNodeID{Scheme: 0x91, NodeID: NodeID{5}}
+	invalid := TypedNodeID{NodeID: NodeID{6}}
+
+	tests := []struct {
+		name     string
+		kind     NodeIDProfileKind
+		pinned   NodeIDScheme
+		allowed  []NodeIDScheme
+		expected map[TypedNodeID]error
+	}{
+		{
+			name:   "strict PQ",
+			kind:   NodeIDProfileStrictPQ,
+			pinned: NodeIDSchemeMLDSA65,
+			expected: map[TypedNodeID]error{
+				mldsa65: nil,
+				mldsa87: ErrNodeIDSchemeMismatch,
+				slhdsa:  ErrNodeIDSchemeMismatch,
+				secp:    ErrNodeIDSchemeMismatch,
+				unknown: ErrNodeIDSchemeUnknown,
+				invalid: ErrNodeIDSchemeInvalid,
+			},
+		},
+		{
+			name:    "strict PQ with SLH-DSA governance validators",
+			kind:    NodeIDProfileStrictPQ,
+			pinned:  NodeIDSchemeMLDSA65,
+			allowed: []NodeIDScheme{NodeIDSchemeSLHDSASHAKE256s},
+			expected: map[TypedNodeID]error{
+				mldsa65: nil,
+				mldsa87: ErrNodeIDSchemeMismatch,
+				slhdsa:  nil,
+				secp:    ErrNodeIDSchemeMismatch,
+			},
+		},
+		{
+			name:   "PQ with classical compat",
+			kind:   NodeIDProfilePQClassicalCompat,
+			pinned: NodeIDSchemeMLDSA87,
+			expected: map[TypedNodeID]error{
+				mldsa65: ErrNodeIDSchemeMismatch,
+				mldsa87: nil,
+				secp:    nil,
+				unknown: ErrNodeIDSchemeUnknown,
+			},
+		},
+		{
+			name:   "classical",
+			kind:   NodeIDProfileClassical,
+			pinned: NodeIDSchemeSecp256k1,
+			expected: map[TypedNodeID]error{
+				mldsa65: ErrNodeIDSchemeMismatch,
+				secp:    nil,
+			},
+		},
+		{
+			name:    "classical migrating to PQ",
+			kind:    NodeIDProfileClassical,
+			pinned:  NodeIDSchemeSecp256k1,
+			allowed: []NodeIDScheme{NodeIDSchemeMLDSA65},
+			expected: map[TypedNodeID]error{
+				mldsa65: nil,
+				mldsa87: ErrNodeIDSchemeMismatch,
+				secp:    nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			p, err := NewNodeIDProfile(tt.kind, tt.pinned, tt.allowed...)
+			require.NoError(err)
+			require.Equal(tt.kind, p.Kind())
+			require.Equal(tt.pinned, p.Pinned())
+			require.Contains(p.Allowed(), tt.pinned)
+			require.Len(p.Allowed(), 1+len(tt.allowed))
+
+			for typed, expectedErr := range tt.expected {
+				require.ErrorIs(p.Check(typed), expectedErr, "scheme %s", typed.Scheme)
+
+				parsed, err := p.CheckWire(typed.Bytes())
+				require.ErrorIs(err, expectedErr, "scheme %s", typed.Scheme)
+				if expectedErr == nil {
+					require.Equal(typed, parsed)
+				}
+			}
+		})
+	}
+}
+
+func TestNodeIDProfile_ZeroValueRejects(t *testing.T) {
+	var p NodeIDProfile
+	err := p.Check(TypedNodeID{Scheme: NodeIDSchemeMLDSA65})
+	require.ErrorIs(t, err, ErrNodeIDSchemeMismatch)
+}
+
+func TestNewNodeIDProfile_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		kind        NodeIDProfileKind
+		pinned      NodeIDScheme
+		allowed     []NodeIDScheme
+		expectedErr error
+	}{
+		{"invalid kind", NodeIDProfileInvalid, NodeIDSchemeMLDSA65, nil, ErrNodeIDSchemeInvalid},
+		{"invalid pinned", NodeIDProfileStrictPQ, NodeIDSchemeInvalid, nil, ErrNodeIDSchemeInvalid},
+		{"unknown pinned", NodeIDProfileStrictPQ, 0x91, nil, ErrNodeIDSchemeUnknown},
+		{"strict PQ pins classical", NodeIDProfileStrictPQ, NodeIDSchemeSecp256k1, nil, ErrNodeIDSchemeInvalid},
+		{"strict PQ allows classical", NodeIDProfileStrictPQ, NodeIDSchemeMLDSA65, []NodeIDScheme{NodeIDSchemeSecp256k1}, ErrNodeIDSchemeInvalid},
+		{"compat allows classical", NodeIDProfilePQClassicalCompat, NodeIDSchemeMLDSA65, []NodeIDScheme{NodeIDSchemeSecp256k1}, ErrNodeIDSchemeInvalid},
+		{"classical pins PQ", NodeIDProfileClassical, NodeIDSchemeMLDSA65, nil, ErrNodeIDSchemeInvalid},
+		{"unknown allowed", NodeIDProfileStrictPQ, NodeIDSchemeMLDSA65, []NodeIDScheme{0x44}, ErrNodeIDSchemeUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewNodeIDProfile(tt.kind, tt.pinned, tt.allowed...)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestNodeIDProfileFromEnv(t *testing.T) {
+	tests := []struct {
+		name         string
+		env          *string
+		pinned       NodeIDScheme
+		expectedKind NodeIDProfileKind
+		expectedErr  error
+	}{
+		{
+			name:         "unset",
+			pinned:       NodeIDSchemeMLDSA65,
+			expectedKind: NodeIDProfileStrictPQ,
+		},
+		{
+			name:         "false",
+			env:          ptr("false"),
+			pinned:       NodeIDSchemeMLDSA65,
+			expectedKind: NodeIDProfileStrictPQ,
+		},
+		{
+			name:         "true",
+			env:          ptr("true"),
+			pinned:       NodeIDSchemeMLDSA65,
+			expectedKind: NodeIDProfilePQClassicalCompat,
+		},
+		{
+			name:         "classical with opt-in",
+			env:          ptr("1"),
+			pinned:       NodeIDSchemeSecp256k1,
+			expectedKind: NodeIDProfileClassical,
+		},
+		{
+			name:        "classical without opt-in",
+			pinned:      NodeIDSchemeSecp256k1,
+			expectedErr: ErrClassicalCompatDisabled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			if tt.env != nil {
+				t.Setenv(ClassicalCompatUnsafeEnv, *tt.env)
+			} else {
+				unsetEnv(t, ClassicalCompatUnsafeEnv)
+			}
+
+			p, err := NodeIDProfileFromEnv(tt.pinned)
+			require.ErrorIs(err, tt.expectedErr)
+			require.Equal(tt.expectedKind, p.Kind())
+		})
+	}
+
+	t.Run("malformed", func(t *testing.T) {
+		t.Setenv(ClassicalCompatUnsafeEnv, "yes please")
+		_, err := NodeIDProfileFromEnv(NodeIDSchemeMLDSA65)
+		require.Error(t, err) //nolint:forbidigo // strconv error is wrapped
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// unsetEnv unsets [key] for the duration of the test.
+func unsetEnv(t *testing.T, key string) {
+	t.Setenv(key, "")
+	require.NoError(t, os.Unsetenv(key))
+}
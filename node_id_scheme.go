@@ -17,6 +17,8 @@
 //	  NodeID    = digest[:20]                               (storage / map key)
 //	  FullDigest = digest                                   (handshake transcript)
 //
+//	strict-PQ (SLH-DSA, FIPS 205): same framing; see node_id_slhdsa.go.
+//
 //	classical (secp256k1 cert, CLASSICAL_COMPAT_UNSAFE only):
 //	  NodeID = RIPEMD160(SHA256(cert.Raw))   (existing NodeIDFromCert behaviour)
 //
@@ -28,7 +30,8 @@
 // The 20-byte truncation provides ~80-bit collision resistance — the same
 // bound the existing RIPEMD160-based NodeID has against a quantum adversary
 // running Grover. Full 384-bit commitment is available via FullDigest() for
-// the validator-set commitment that pins post-quantum security at genesis.
+// the validator-set commitment that pins post-quantum security at genesis
+// (validator_set.go).
 
 package ids
 
@@ -37,6 +40,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"strings"
 
 	"golang.org/x/crypto/sha3"
 )
@@ -49,11 +53,14 @@ import (
 //	0x00       — Invalid (never accepted)
 //	0x42       — ML-DSA-65   (FIPS 204 Cat 3, canonical strict-PQ)
 //	0x43       — ML-DSA-87   (FIPS 204 Cat 5, high-value validators)
+//	0x50–0x5b  — SLH-DSA     (FIPS 205, one byte per parameter set;
+//	             see node_id_slhdsa.go)
 //	0x90       — secp256k1   (classical; accepted ONLY under
 //	             LUX_CLASSICAL_COMPAT_UNSAFE)
 //
-// New schemes claim the next free byte in the matching consensus block.
-// Reuse is forbidden — a retired byte stays retired.
+// New schemes claim the next free byte in the matching consensus block and
+// are added with RegisterNodeIDScheme. Reuse is forbidden — a retired byte
+// stays retired, and the registry enforces it.
 type NodeIDScheme uint8
 
 const (
@@ -78,47 +85,97 @@ const (
 	NodeIDSchemeSecp256k1 NodeIDScheme = 0x90
 )
 
-// String returns the canonical wire name of this scheme.
+// String returns the canonical wire name of this scheme, as registered.
+// Retired schemes keep their name so old logs stay readable.
 func (s NodeIDScheme) String() string {
-	switch s {
-	case NodeIDSchemeInvalid:
+	if s == NodeIDSchemeInvalid {
 		return "invalid"
-	case NodeIDSchemeMLDSA65:
-		return "ml-dsa-65"
-	case NodeIDSchemeMLDSA87:
-		return "ml-dsa-87"
-	case NodeIDSchemeSecp256k1:
-		return "secp256k1-classical-compat-unsafe"
+	}
+	if d, ok := nodeIDSchemes.lookup(s); ok {
+		return d.Name
+	}
+	return fmt.Sprintf("node-id-scheme(0x%02x)", uint8(s))
+}
+
+// NodeIDSchemeFromString is the inverse of NodeIDScheme.String for the
+// schemes this build understands. The "invalid" name is refused with
+// ErrNodeIDSchemeInvalid; any other unrecognised name, including a retired
+// scheme's name and the "node-id-scheme(0x..)" rendering of an unknown
+// byte, is refused with ErrNodeIDSchemeUnknown.
+func NodeIDSchemeFromString(name string) (NodeIDScheme, error) {
+	if name == NodeIDSchemeInvalid.String() {
+		return NodeIDSchemeInvalid, fmt.Errorf("%w: %q", ErrNodeIDSchemeInvalid, name)
+	}
+	d, ok := nodeIDSchemes.byName(name)
+	if !ok || d.Retired {
+		return NodeIDSchemeInvalid, fmt.Errorf("%w: %q", ErrNodeIDSchemeUnknown, name)
+	}
+	return d.Scheme, nil
+}
+
+// MarshalText returns the canonical name of this scheme. JSON encodes a
+// NodeIDScheme through this method, as a string. NodeIDSchemeInvalid and
+// unknown bytes have no canonical name and are refused.
+func (s NodeIDScheme) MarshalText() ([]byte, error) {
+	return s.AppendText(nil)
+}
+
+// AppendText appends the canonical name of this scheme to [b]. See
+// MarshalText. On error, [b] is returned unchanged.
+func (s NodeIDScheme) AppendText(b []byte) ([]byte, error) {
+	if err := s.checkKnown(); err != nil {
+		return b, err
+	}
+	return append(b, s.String()...), nil
+}
+
+// UnmarshalText is the inverse of MarshalText. See NodeIDSchemeFromString.
+func (s *NodeIDScheme) UnmarshalText(text []byte) error {
+	parsed, err := NodeIDSchemeFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*s = parsed
+	return nil
+}
+
+// checkKnown returns ErrNodeIDSchemeInvalid for NodeIDSchemeInvalid and
+// ErrNodeIDSchemeUnknown for any other byte this build does not understand.
+func (s NodeIDScheme) checkKnown() error {
+	switch {
+	case s == NodeIDSchemeInvalid:
+		return fmt.Errorf("%w: scheme=%s", ErrNodeIDSchemeInvalid, s.String())
+	case !s.IsKnown():
+		return fmt.Errorf("%w: scheme=0x%02x", ErrNodeIDSchemeUnknown, uint8(s))
 	default:
-		return fmt.Sprintf("node-id-scheme(0x%02x)", uint8(s))
+		return nil
 	}
 }
 
-// IsPostQuantum reports whether this scheme is post-quantum (FIPS 204
-// ML-DSA family). Strict-PQ profiles refuse any NodeID whose scheme is
-// not post-quantum.
+// IsPostQuantum reports whether this scheme is registered, not retired,
+// and in NodeIDSchemeClassPostQuantum. Strict-PQ profiles refuse any
+// NodeID whose scheme is not post-quantum.
 func (s NodeIDScheme) IsPostQuantum() bool {
-	return s == NodeIDSchemeMLDSA65 || s == NodeIDSchemeMLDSA87
+	d, ok := nodeIDSchemes.active(s)
+	return ok && d.Class == NodeIDSchemeClassPostQuantum
 }
 
-// IsClassicalCompatUnsafe reports whether this scheme is in the classical
-// compatibility block (0x90+). These schemes are accepted only under an
-// explicit operator opt-in; the strict-PQ profile refuses them.
+// IsClassicalCompatUnsafe reports whether this scheme is registered, not
+// retired, and in NodeIDSchemeClassClassicalCompatUnsafe. These schemes
+// are accepted only under an explicit operator opt-in; the strict-PQ
+// profile refuses them.
 func (s NodeIDScheme) IsClassicalCompatUnsafe() bool {
-	return s == NodeIDSchemeSecp256k1
+	d, ok := nodeIDSchemes.active(s)
+	return ok && d.Class == NodeIDSchemeClassClassicalCompatUnsafe
 }
 
-// IsKnown reports whether this byte names a scheme this build understands.
-// An unknown byte is rejected by every gate — including the
-// classical-compat path, which only accepts the named secp256k1 scheme,
+// IsKnown reports whether this byte names a registered, non-retired
+// scheme. An unknown byte is rejected by every gate — including the
+// classical-compat path, which only accepts registered classical schemes,
 // not arbitrary 0x90+ bytes.
 func (s NodeIDScheme) IsKnown() bool {
-	switch s {
-	case NodeIDSchemeMLDSA65, NodeIDSchemeMLDSA87, NodeIDSchemeSecp256k1:
-		return true
-	default:
-		return false
-	}
+	_, ok := nodeIDSchemes.active(s)
+	return ok
 }
 
 // nodeIDDomainPrefix is the SP 800-185 customization string for the
@@ -157,6 +214,32 @@ func (s NodeIDScheme) DeriveMLDSA(chainID ID, pubKey []byte) (NodeID, FullDigest
 		return EmptyNodeID, FullDigest{}, fmt.Errorf("%w: scheme=%s is not ML-DSA",
 			ErrNodeIDSchemeInvalid, s.String())
 	}
+	return s.Derive(chainID, pubKey)
+}
+
+// Derive returns the NodeID and FullDigest for [pubKey] under [chainID]
+// using the derivation function registered for this scheme. An
+// unregistered or retired scheme is refused with ErrNodeIDSchemeUnknown; a
+// scheme with no public-key derivation (secp256k1, whose NodeID comes from
+// the staking certificate) is refused with ErrNodeIDSchemeInvalid.
+func (s NodeIDScheme) Derive(chainID ID, pubKey []byte) (NodeID, FullDigest, error) {
+	d, ok := nodeIDSchemes.active(s)
+	if !ok {
+		return EmptyNodeID, FullDigest{}, fmt.Errorf("%w: scheme=0x%02x",
+			ErrNodeIDSchemeUnknown, uint8(s))
+	}
+	if d.Derive == nil {
+		return EmptyNodeID, FullDigest{}, fmt.Errorf("%w: scheme=%s has no public-key derivation",
+			ErrNodeIDSchemeInvalid, s.String())
+	}
+	return d.Derive(s, chainID, pubKey)
+}
+
+// deriveNodeIDV1 is the NODE_ID_V1 derivation documented on DeriveMLDSA.
+// It is registered for every built-in post-quantum scheme; the scheme byte
+// is part of the input, so the same key bytes never produce the same
+// NodeID under two schemes.
+func deriveNodeIDV1(s NodeIDScheme, chainID ID, pubKey []byte) (NodeID, FullDigest, error) {
 	if len(pubKey) == 0 {
 		return EmptyNodeID, FullDigest{}, fmt.Errorf("%w: empty public key",
 			ErrNodeIDSchemeInvalid)
@@ -244,17 +327,50 @@ func (t TypedNodeID) Bytes() []byte {
 	return out
 }
 
+// MarshalBinary returns the 21-byte wire form. Unlike Bytes, it refuses a
+// scheme the wire decoder would reject, so every successful MarshalBinary
+// round-trips through UnmarshalBinary.
+func (t TypedNodeID) MarshalBinary() ([]byte, error) {
+	b, err := t.AppendBinary(make([]byte, 0, TypedNodeIDLen))
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// AppendBinary appends the 21-byte wire form to [b]. See MarshalBinary. On
+// error, [b] is returned unchanged.
+func (t TypedNodeID) AppendBinary(b []byte) ([]byte, error) {
+	if err := t.Scheme.checkKnown(); err != nil {
+		return b, err
+	}
+	b = append(b, byte(t.Scheme))
+	return append(b, t.NodeID[:]...), nil
+}
+
+// UnmarshalBinary is the inverse of MarshalBinary, with the same gates as
+// ParseTypedNodeID.
+func (t *TypedNodeID) UnmarshalBinary(b []byte) error {
+	parsed, err := ParseTypedNodeID(b)
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
 // ParseTypedNodeID is the inverse of TypedNodeID.Bytes. Refuses any input
-// that is the wrong length, names NodeIDSchemeInvalid, or names an
-// unknown scheme byte. The scheme byte is checked before the NodeID copy
-// so a malformed input cannot consume the array space.
+// that is the wrong length, names NodeIDSchemeInvalid, or names a scheme
+// byte that is not registered or has been retired. The scheme byte is
+// checked before the NodeID copy so a malformed input cannot consume the
+// array space.
 func ParseTypedNodeID(b []byte) (TypedNodeID, error) {
 	if len(b) != TypedNodeIDLen {
 		return TypedNodeID{}, fmt.Errorf("%w: got %d bytes, want %d",
 			ErrTypedNodeIDLen, len(b), TypedNodeIDLen)
 	}
 	s := NodeIDScheme(b[0])
-	if !s.IsKnown() {
+	if _, ok := nodeIDSchemes.active(s); !ok {
 		return TypedNodeID{}, fmt.Errorf("%w: scheme=0x%02x",
 			ErrNodeIDSchemeUnknown, b[0])
 	}
@@ -264,7 +380,8 @@ func ParseTypedNodeID(b []byte) (TypedNodeID, error) {
 }
 
 // Compare orders TypedNodeIDs lexicographically over (scheme, id). Used
-// for deterministic sorting in validator-set commitments.
+// for deterministic sorting in validator-set commitments (see
+// ValidatorSetCommitment).
 func (t TypedNodeID) Compare(other TypedNodeID) int {
 	if t.Scheme != other.Scheme {
 		if t.Scheme < other.Scheme {
@@ -275,9 +392,63 @@ func (t TypedNodeID) Compare(other TypedNodeID) int {
 	return bytes.Compare(t.NodeID[:], other.NodeID[:])
 }
 
-// String returns "scheme:NodeID-<cb58>" for logging. Not a wire form.
+// typedNodeIDSep separates the scheme name from the NodeID in the text form
+// of a TypedNodeID. Scheme names never contain it.
+const typedNodeIDSep = ":"
+
+var errMissingTypedNodeIDSep = errors.New("ids: TypedNodeID text is missing the scheme separator")
+
+// String returns "scheme:NodeID-<cb58>". For a known scheme this is also
+// the canonical text form produced by MarshalText and used by JSON,
+// validator APIs and genesis files; the binary wire form remains Bytes.
 func (t TypedNodeID) String() string {
-	return fmt.Sprintf("%s:%s", t.Scheme.String(), t.NodeID.String())
+	return t.Scheme.String() + typedNodeIDSep + t.NodeID.String()
+}
+
+// TypedNodeIDFromString is the inverse of TypedNodeID.String for known
+// schemes, e.g. "ml-dsa-65:NodeID-...". The scheme is parsed with
+// NodeIDSchemeFromString, so NodeIDSchemeInvalid and unknown names are
+// refused with the same typed errors as the wire decoder.
+func TypedNodeIDFromString(s string) (TypedNodeID, error) {
+	schemeStr, nodeIDStr, ok := strings.Cut(s, typedNodeIDSep)
+	if !ok {
+		return TypedNodeID{}, fmt.Errorf("%w: %q", errMissingTypedNodeIDSep, s)
+	}
+	scheme, err := NodeIDSchemeFromString(schemeStr)
+	if err != nil {
+		return TypedNodeID{}, err
+	}
+	id, err := NodeIDFromString(nodeIDStr)
+	if err != nil {
+		return TypedNodeID{}, err
+	}
+	return TypedNodeID{Scheme: scheme, NodeID: id}, nil
+}
+
+// MarshalText returns the canonical text form (see String). JSON encodes a
+// TypedNodeID through this method, as a string. A scheme the decoder would
+// refuse is refused here too.
+func (t TypedNodeID) MarshalText() ([]byte, error) {
+	return t.AppendText(nil)
+}
+
+// AppendText appends the canonical text form to [b]. See MarshalText. On
+// error, [b] is returned unchanged.
+func (t TypedNodeID) AppendText(b []byte) ([]byte, error) {
+	if err := t.Scheme.checkKnown(); err != nil {
+		return b, err
+	}
+	return append(b, t.String()...), nil
+}
+
+// UnmarshalText is the inverse of MarshalText. See TypedNodeIDFromString.
+func (t *TypedNodeID) UnmarshalText(text []byte) error {
+	parsed, err := TypedNodeIDFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
 }
 
 // TypedNodeIDFromCert produces a classical-compat TypedNodeID from a
@@ -330,6 +501,6 @@ var (
 	// ErrNodeIDSchemeMismatch — a TypedNodeID's scheme byte does not
 	// match the scheme the consensus profile pins. This is the cross-axis
 	// gate that catches a primitive-mismatch downgrade attempt at the
-	// chain boundary.
+	// chain boundary; see NodeIDProfile.Check.
 	ErrNodeIDSchemeMismatch = errors.New("ids: NodeIDScheme does not match profile")
 )
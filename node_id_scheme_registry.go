@@ -0,0 +1,278 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// node_id_scheme_registry.go — the table of NodeIDSchemes this build
+// understands.
+//
+// Every question the rest of the package asks about a scheme byte (is it
+// known, what is it called, is it post-quantum, how is a NodeID derived
+// from its public key) is answered from this registry. Adding a signature
+// family is one RegisterNodeIDScheme call at init; no switch elsewhere
+// needs editing.
+//
+// The registry enforces the numbering rule from NodeIDScheme: a byte, once
+// registered, is never re-registered, and a retired byte stays retired. A
+// retired scheme keeps its name for logs but is refused by every decoder
+// and gate, exactly like an unknown byte. Schemes are retired by
+// registering their descriptor with Retired set, never at runtime.
+//
+// The registry is read on every wire decode, so reads are lock-free: the
+// table is copy-on-write behind an atomic pointer. It is filled from init
+// and frozen by FreezeNodeIDSchemes, after which the answer for a byte
+// cannot change while the process runs.
+
+package ids
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// NodeIDSchemeClass is the security class of a NodeIDScheme.
+type NodeIDSchemeClass uint8
+
+const (
+	// NodeIDSchemeClassInvalid is the zero value and is never registered.
+	NodeIDSchemeClassInvalid NodeIDSchemeClass = iota
+
+	// NodeIDSchemeClassPostQuantum schemes are accepted by strict-PQ
+	// profiles.
+	NodeIDSchemeClassPostQuantum
+
+	// NodeIDSchemeClassClassicalCompatUnsafe schemes are accepted only
+	// under an explicit LUX_CLASSICAL_COMPAT_UNSAFE opt-in.
+	NodeIDSchemeClassClassicalCompatUnsafe
+)
+
+// String returns a short name for this class.
+func (c NodeIDSchemeClass) String() string {
+	switch c {
+	case NodeIDSchemeClassPostQuantum:
+		return "post-quantum"
+	case NodeIDSchemeClassClassicalCompatUnsafe:
+		return "classical-compat-unsafe"
+	default:
+		return fmt.Sprintf("node-id-scheme-class(%d)", uint8(c))
+	}
+}
+
+// NodeIDDeriveFunc derives the NodeID and its FullDigest from [pubKey]
+// under [chainID] for [scheme]. The scheme is passed in so one function
+// can serve a whole family.
+type NodeIDDeriveFunc func(scheme NodeIDScheme, chainID ID, pubKey []byte) (NodeID, FullDigest, error)
+
+// NodeIDSchemeDescriptor describes one NodeIDScheme.
+type NodeIDSchemeDescriptor struct {
+	// Scheme is the wire byte. NodeIDSchemeInvalid cannot be registered.
+	Scheme NodeIDScheme
+
+	// Name is the canonical name returned by NodeIDScheme.String and
+	// accepted by NodeIDSchemeFromString. It must be non-empty, lowercase,
+	// unique, and must not contain the TypedNodeID text separator.
+	Name string
+
+	// Class is the security class of the scheme.
+	Class NodeIDSchemeClass
+
+	// Derive derives a NodeID from a public key. It is nil for schemes
+	// whose NodeID is not derived from a bare public key, such as
+	// secp256k1, whose NodeID comes from the staking certificate.
+	Derive NodeIDDeriveFunc
+
+	// Retired reserves the byte and name without accepting them. A scheme
+	// registered as retired can never be registered again.
+	Retired bool
+}
+
+var (
+	// ErrNodeIDSchemeRegistered — RegisterNodeIDScheme was given a byte or
+	// name that is already registered.
+	ErrNodeIDSchemeRegistered = errors.New("ids: NodeIDScheme is already registered")
+
+	// ErrNodeIDSchemeRetired — RegisterNodeIDScheme was given a byte or
+	// name that belongs to a retired scheme.
+	ErrNodeIDSchemeRetired = errors.New("ids: NodeIDScheme is retired")
+
+	// ErrNodeIDSchemeRegistryFrozen — RegisterNodeIDScheme was called after
+	// FreezeNodeIDSchemes.
+	ErrNodeIDSchemeRegistryFrozen = errors.New("ids: NodeIDScheme registry is frozen")
+)
+
+// nodeIDSchemes is the process-wide registry.
+var nodeIDSchemes = newNodeIDSchemeRegistry()
+
+func init() {
+	for _, d := range []NodeIDSchemeDescriptor{
+		{
+			Scheme: NodeIDSchemeMLDSA65,
+			Name:   "ml-dsa-65",
+			Class:  NodeIDSchemeClassPostQuantum,
+			Derive: deriveNodeIDV1,
+		},
+		{
+			Scheme: NodeIDSchemeMLDSA87,
+			Name:   "ml-dsa-87",
+			Class:  NodeIDSchemeClassPostQuantum,
+			Derive: deriveNodeIDV1,
+		},
+		{
+			Scheme: NodeIDSchemeSecp256k1,
+			Name:   "secp256k1-classical-compat-unsafe",
+			Class:  NodeIDSchemeClassClassicalCompatUnsafe,
+		},
+	} {
+		if err := RegisterNodeIDScheme(d); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterNodeIDScheme adds [d] to the registry. It should be called from
+// init; registrations are permanent. A NodeIDScheme read before its
+// registration, e.g. by another package's init, is reported as unknown by
+// that read only.
+//
+// Registration fails with ErrNodeIDSchemeRegistryFrozen after
+// FreezeNodeIDSchemes, with ErrNodeIDSchemeRetired if the byte or name belongs to
+// a retired scheme, with ErrNodeIDSchemeRegistered if either is already in
+// use, and with ErrNodeIDSchemeInvalid if the descriptor itself is
+// malformed.
+func RegisterNodeIDScheme(d NodeIDSchemeDescriptor) error {
+	return nodeIDSchemes.register(d)
+}
+
+// FreezeNodeIDSchemes closes the registry to further registrations.
+// Applications call it once every package has been initialized, e.g. at the
+// start of main, so that the set of schemes cannot change while NodeIDs are
+// in use. Calling it again has no effect.
+func FreezeNodeIDSchemes() {
+	nodeIDSchemes.freeze()
+}
+
+// LookupNodeIDScheme returns the descriptor registered for [s], including
+// retired schemes.
+func LookupNodeIDScheme(s NodeIDScheme) (NodeIDSchemeDescriptor, bool) {
+	return nodeIDSchemes.lookup(s)
+}
+
+// NodeIDSchemes returns every registered descriptor, including retired
+// schemes, in byte order.
+func NodeIDSchemes() []NodeIDSchemeDescriptor {
+	return nodeIDSchemes.list()
+}
+
+type nodeIDSchemeRegistry struct {
+	// lock serializes registration and freezing; reads only load table.
+	lock   sync.Mutex
+	frozen bool
+	table  atomic.Pointer[nodeIDSchemeTable]
+}
+
+// nodeIDSchemeTable is never modified once published.
+type nodeIDSchemeTable struct {
+	schemes [256]*NodeIDSchemeDescriptor
+	names   map[string]NodeIDScheme
+}
+
+func newNodeIDSchemeRegistry() *nodeIDSchemeRegistry {
+	r := &nodeIDSchemeRegistry{}
+	r.table.Store(&nodeIDSchemeTable{
+		names: make(map[string]NodeIDScheme),
+	})
+	return r
+}
+
+func (r *nodeIDSchemeRegistry) register(d NodeIDSchemeDescriptor) error {
+	switch {
+	case d.Scheme == NodeIDSchemeInvalid:
+		return fmt.Errorf("%w: cannot register scheme 0x00", ErrNodeIDSchemeInvalid)
+	case d.Name == "" || d.Name == NodeIDSchemeInvalid.String():
+		return fmt.Errorf("%w: scheme=0x%02x has reserved name %q", ErrNodeIDSchemeInvalid, uint8(d.Scheme), d.Name)
+	case d.Name != strings.ToLower(d.Name) || strings.Contains(d.Name, typedNodeIDSep):
+		return fmt.Errorf("%w: scheme=0x%02x has malformed name %q", ErrNodeIDSchemeInvalid, uint8(d.Scheme), d.Name)
+	case d.Class != NodeIDSchemeClassPostQuantum && d.Class != NodeIDSchemeClassClassicalCompatUnsafe:
+		return fmt.Errorf("%w: scheme=0x%02x has class %s", ErrNodeIDSchemeInvalid, uint8(d.Scheme), d.Class)
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.frozen {
+		return fmt.Errorf("%w: cannot register scheme=0x%02x (%s)", ErrNodeIDSchemeRegistryFrozen, uint8(d.Scheme), d.Name)
+	}
+	t := r.table.Load()
+	if existing := t.schemes[d.Scheme]; existing != nil {
+		if existing.Retired {
+			return fmt.Errorf("%w: scheme=0x%02x (%s)", ErrNodeIDSchemeRetired, uint8(d.Scheme), existing.Name)
+		}
+		return fmt.Errorf("%w: scheme=0x%02x (%s)", ErrNodeIDSchemeRegistered, uint8(d.Scheme), existing.Name)
+	}
+	if s, ok := t.names[d.Name]; ok {
+		if t.schemes[s].Retired {
+			return fmt.Errorf("%w: name %q (scheme=0x%02x)", ErrNodeIDSchemeRetired, d.Name, uint8(s))
+		}
+		return fmt.Errorf("%w: name %q (scheme=0x%02x)", ErrNodeIDSchemeRegistered, d.Name, uint8(s))
+	}
+
+	next := &nodeIDSchemeTable{
+		schemes: t.schemes,
+		names:   maps.Clone(t.names),
+	}
+	next.schemes[d.Scheme] = &d
+	next.names[d.Name] = d.Scheme
+	r.table.Store(next)
+	return nil
+}
+
+func (r *nodeIDSchemeRegistry) freeze() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.frozen = true
+}
+
+func (r *nodeIDSchemeRegistry) read() *nodeIDSchemeTable {
+	return r.table.Load()
+}
+
+func (r *nodeIDSchemeRegistry) lookup(s NodeIDScheme) (NodeIDSchemeDescriptor, bool) {
+	d := r.read().schemes[s]
+	if d == nil {
+		return NodeIDSchemeDescriptor{}, false
+	}
+	return *d, true
+}
+
+// active returns the descriptor for [s] if it is registered and not
+// retired.
+func (r *nodeIDSchemeRegistry) active(s NodeIDScheme) (NodeIDSchemeDescriptor, bool) {
+	d, ok := r.lookup(s)
+	if !ok || d.Retired {
+		return NodeIDSchemeDescriptor{}, false
+	}
+	return d, true
+}
+
+func (r *nodeIDSchemeRegistry) byName(name string) (NodeIDSchemeDescriptor, bool) {
+	t := r.read()
+	s, ok := t.names[name]
+	if !ok {
+		return NodeIDSchemeDescriptor{}, false
+	}
+	return *t.schemes[s], true
+}
+
+func (r *nodeIDSchemeRegistry) list() []NodeIDSchemeDescriptor {
+	t := r.read()
+	descriptors := make([]NodeIDSchemeDescriptor, 0, len(t.names))
+	for _, d := range t.schemes {
+		if d != nil {
+			descriptors = append(descriptors, *d)
+		}
+	}
+	return descriptors
+}
@@ -0,0 +1,192 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestNodeIDSchemeRegistry_BuiltinSchemes — the built-in schemes are
+// registered through the same mechanism as any new scheme, and the
+// NodeIDScheme predicates read from it.
+func TestNodeIDSchemeRegistry_BuiltinSchemes(t *testing.T) {
+	require := require.New(t)
+
+	descriptors := NodeIDSchemes()
+	require.Len(descriptors, 3+len(slhDSAParams))
+	require.Equal(NodeIDSchemeMLDSA65, descriptors[0].Scheme)
+	require.Equal(NodeIDSchemeMLDSA87, descriptors[1].Scheme)
+	require.Equal(NodeIDSchemeSLHDSASHA2128s, descriptors[2].Scheme)
+	require.Equal(NodeIDSchemeSecp256k1, descriptors[len(descriptors)-1].Scheme)
+
+	for _, d := range descriptors {
+		require.Equal(d.Name, d.Scheme.String())
+		require.True(d.Scheme.IsKnown())
+		require.False(d.Retired)
+		require.Equal(d.Class == NodeIDSchemeClassPostQuantum, d.Scheme.IsPostQuantum())
+		require.Equal(d.Class == NodeIDSchemeClassClassicalCompatUnsafe, d.Scheme.IsClassicalCompatUnsafe())
+	}
+
+	_, ok := LookupNodeIDScheme(NodeIDSchemeInvalid)
+	require.False(ok)
+	_, ok = LookupNodeIDScheme(0x44)
+	require.False(ok)
+}
+
+// TestNodeIDSchemeRegistry_Derive — Derive dispatches to the registered
+// function; DeriveMLDSA is the same derivation restricted to ML-DSA.
+func TestNodeIDSchemeRegistry_Derive(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ID{0x01}
+	pubKey := []byte("ml-dsa-65-public-key")
+
+	id1, full1, err := NodeIDSchemeMLDSA65.Derive(chainID, pubKey)
+	require.NoError(err)
+	id2, full2, err := NodeIDSchemeMLDSA65.DeriveMLDSA(chainID, pubKey)
+	require.NoError(err)
+	require.Equal(id1, id2)
+	require.Equal(full1, full2)
+
+	_, _, err = NodeIDSchemeSecp256k1.Derive(chainID, pubKey)
+	require.ErrorIs(err, ErrNodeIDSchemeInvalid)
+	_, _, err = NodeIDScheme(0x44).Derive(chainID, pubKey)
+	require.ErrorIs(err, ErrNodeIDSchemeUnknown)
+}
+
+// TestNodeIDSchemeRegistry_Register — registration rejects malformed
+// descriptors and any reuse of a byte or name.
+func TestNodeIDSchemeRegistry_Register(t *testing.T) {
+	valid := NodeIDSchemeDescriptor{
+		Scheme: 0x60,
+		Name:   "falcon-512",
+		Class:  NodeIDSchemeClassPostQuantum,
+		Derive: deriveNodeIDV1,
+	}
+	tests := []struct {
+		name        string
+		d           NodeIDSchemeDescriptor
+		expectedErr error
+	}{
+		{
+			name:        "invalid byte",
+			d:           NodeIDSchemeDescriptor{Name: "zero", Class: NodeIDSchemeClassPostQuantum},
+			expectedErr: ErrNodeIDSchemeInvalid,
+		},
+		{
+			name:        "empty name",
+			d:           NodeIDSchemeDescriptor{Scheme: 0x61, Class: NodeIDSchemeClassPostQuantum},
+			expectedErr: ErrNodeIDSchemeInvalid,
+		},
+		{
+			name:        "reserved name",
+			d:           NodeIDSchemeDescriptor{Scheme: 0x61, Name: "invalid", Class: NodeIDSchemeClassPostQuantum},
+			expectedErr: ErrNodeIDSchemeInvalid,
+		},
+		{
+			name:        "uppercase name",
+			d:           NodeIDSchemeDescriptor{Scheme: 0x61, Name: "Falcon-1024", Class: NodeIDSchemeClassPostQuantum},
+			expectedErr: ErrNodeIDSchemeInvalid,
+		},
+		{
+			name:        "separator in name",
+			d:           NodeIDSchemeDescriptor{Scheme: 0x61, Name: "falcon:1024", Class: NodeIDSchemeClassPostQuantum},
+			expectedErr: ErrNodeIDSchemeInvalid,
+		},
+		{
+			name:        "missing class",
+			d:           NodeIDSchemeDescriptor{Scheme: 0x61, Name: "falcon-1024"},
+			expectedErr: ErrNodeIDSchemeInvalid,
+		},
+		{
+			name:        "duplicate byte",
+			d:           NodeIDSchemeDescriptor{Scheme: 0x60, Name: "falcon-1024", Class: NodeIDSchemeClassPostQuantum},
+			expectedErr: ErrNodeIDSchemeRegistered,
+		},
+		{
+			name:        "duplicate name",
+			d:           NodeIDSchemeDescriptor{Scheme: 0x61, Name: "falcon-512", Class: NodeIDSchemeClassPostQuantum},
+			expectedErr: ErrNodeIDSchemeRegistered,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newNodeIDSchemeRegistry()
+			require.NoError(t, r.register(valid))
+			require.ErrorIs(t, r.register(tt.d), tt.expectedErr)
+		})
+	}
+}
+
+// TestNodeIDSchemeRegistry_RetiredStaysRetired — a scheme registered as
+// retired is refused like an unknown byte, and neither its byte nor its
+// name can be registered again.
+func TestNodeIDSchemeRegistry_RetiredStaysRetired(t *testing.T) {
+	require := require.New(t)
+
+	r := newNodeIDSchemeRegistry()
+	d := NodeIDSchemeDescriptor{
+		Scheme:  0x60,
+		Name:    "falcon-512",
+		Class:   NodeIDSchemeClassPostQuantum,
+		Retired: true,
+	}
+	require.NoError(r.register(d))
+
+	d.Retired = false
+	require.ErrorIs(r.register(d), ErrNodeIDSchemeRetired)
+	require.ErrorIs(r.register(NodeIDSchemeDescriptor{
+		Scheme: 0x61,
+		Name:   "falcon-512",
+		Class:  NodeIDSchemeClassPostQuantum,
+	}), ErrNodeIDSchemeRetired)
+
+	_, ok := r.active(0x60)
+	require.False(ok)
+	retired, ok := r.lookup(0x60)
+	require.True(ok)
+	require.True(retired.Retired)
+	require.Equal("falcon-512", retired.Name)
+}
+
+// TestNodeIDSchemeRegistry_Freeze — reads do not close the registry, so a
+// package that reads schemes during init cannot break a registration made
+// by a package initialized after it; only an explicit freeze does.
+func TestNodeIDSchemeRegistry_Freeze(t *testing.T) {
+	require := require.New(t)
+
+	r := newNodeIDSchemeRegistry()
+	_, ok := r.active(0x60)
+	require.False(ok)
+	require.Empty(r.list())
+
+	require.NoError(r.register(NodeIDSchemeDescriptor{
+		Scheme: 0x60,
+		Name:   "falcon-512",
+		Class:  NodeIDSchemeClassPostQuantum,
+	}))
+	_, ok = r.active(0x60)
+	require.True(ok, "a scheme registered after a read is visible")
+
+	r.freeze()
+	r.freeze()
+	require.ErrorIs(r.register(NodeIDSchemeDescriptor{
+		Scheme: 0x61,
+		Name:   "falcon-1024",
+		Class:  NodeIDSchemeClassPostQuantum,
+	}), ErrNodeIDSchemeRegistryFrozen)
+	_, ok = r.active(0x61)
+	require.False(ok)
+	require.Len(r.list(), 1)
+}
+
+func BenchmarkNodeIDSchemeIsKnown(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = NodeIDSchemeMLDSA65.IsKnown()
+		}
+	})
+}
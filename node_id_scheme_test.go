@@ -4,6 +4,7 @@
 package ids
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -258,6 +259,134 @@ func TestTypedNodeID_FromCert_IsClassicalCompat(t *testing.T) {
 	require.False(t1.Scheme.IsPostQuantum())
 }
 
+// TestNodeIDScheme_Text_RoundTrip — every known scheme round-trips through
+// its String name, including as a JSON string.
+func TestNodeIDScheme_Text_RoundTrip(t *testing.T) {
+	for _, s := range []NodeIDScheme{NodeIDSchemeMLDSA65, NodeIDSchemeMLDSA87, NodeIDSchemeSecp256k1} {
+		t.Run(s.String(), func(t *testing.T) {
+			require := require.New(t)
+
+			text, err := s.MarshalText()
+			require.NoError(err)
+			require.Equal(s.String(), string(text))
+
+			parsed, err := NodeIDSchemeFromString(string(text))
+			require.NoError(err)
+			require.Equal(s, parsed)
+
+			j, err := json.Marshal(s)
+			require.NoError(err)
+			require.Equal(`"`+s.String()+`"`, string(j))
+
+			var decoded NodeIDScheme
+			require.NoError(json.Unmarshal(j, &decoded))
+			require.Equal(s, decoded)
+		})
+	}
+}
+
+// TestNodeIDScheme_Text_RejectsInvalidAndUnknown — neither the invalid
+// sentinel nor an unknown byte has a canonical text form.
+func TestNodeIDScheme_Text_RejectsInvalidAndUnknown(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NodeIDSchemeInvalid.MarshalText()
+	require.ErrorIs(err, ErrNodeIDSchemeInvalid)
+	_, err = NodeIDScheme(0x91).MarshalText()
+	require.ErrorIs(err, ErrNodeIDSchemeUnknown)
+	_, err = json.Marshal(NodeIDScheme(0x91))
+	require.ErrorIs(err, ErrNodeIDSchemeUnknown)
+	b, err := NodeIDScheme(0x91).AppendText([]byte("scheme="))
+	require.ErrorIs(err, ErrNodeIDSchemeUnknown)
+	require.Equal([]byte("scheme="), b, "a failed append must return the input")
+
+	_, err = NodeIDSchemeFromString("invalid")
+	require.ErrorIs(err, ErrNodeIDSchemeInvalid)
+	_, err = NodeIDSchemeFromString(NodeIDScheme(0x91).String())
+	require.ErrorIs(err, ErrNodeIDSchemeUnknown)
+	_, err = NodeIDSchemeFromString("ML-DSA-65")
+	require.ErrorIs(err, ErrNodeIDSchemeUnknown)
+
+	s := NodeIDSchemeMLDSA87
+	require.ErrorIs(json.Unmarshal([]byte(`"invalid"`), &s), ErrNodeIDSchemeInvalid)
+	require.Equal(NodeIDSchemeMLDSA87, s, "a failed unmarshal must not modify the receiver")
+}
+
+// TestTypedNodeID_Text_RoundTrip — the canonical text form is String and
+// round-trips through MarshalText, AppendText and JSON.
+func TestTypedNodeID_Text_RoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	typed := TypedNodeID{Scheme: NodeIDSchemeMLDSA65, NodeID: NodeID{0x01, 0x02}}
+
+	text, err := typed.MarshalText()
+	require.NoError(err)
+	require.Equal("ml-dsa-65:"+typed.NodeID.String(), string(text))
+	require.Equal(typed.String(), string(text))
+
+	appended, err := typed.AppendText([]byte("node="))
+	require.NoError(err)
+	require.Equal("node="+typed.String(), string(appended))
+
+	parsed, err := TypedNodeIDFromString(string(text))
+	require.NoError(err)
+	require.Equal(typed, parsed)
+
+	type genesisValidator struct {
+		NodeID TypedNodeID  `json:"nodeID"`
+		Scheme NodeIDScheme `json:"scheme"`
+	}
+	in := genesisValidator{NodeID: typed, Scheme: typed.Scheme}
+	j, err := json.Marshal(in)
+	require.NoError(err)
+	require.JSONEq(`{"nodeID":"`+typed.String()+`","scheme":"ml-dsa-65"}`, string(j))
+
+	var out genesisValidator
+	require.NoError(json.Unmarshal(j, &out))
+	require.Equal(in, out)
+
+	// As a map key.
+	m := map[TypedNodeID]uint64{typed: 7}
+	j, err = json.Marshal(m)
+	require.NoError(err)
+	var decodedMap map[TypedNodeID]uint64
+	require.NoError(json.Unmarshal(j, &decodedMap))
+	require.Equal(m, decodedMap)
+}
+
+// TestTypedNodeID_Text_Rejects — the text decoder refuses the same schemes
+// as the wire decoder, plus malformed text.
+func TestTypedNodeID_Text_Rejects(t *testing.T) {
+	nodeIDStr := NodeID{0x01}.String()
+	tests := []struct {
+		name        string
+		in          string
+		expectedErr error
+	}{
+		{"invalid scheme", "invalid:" + nodeIDStr, ErrNodeIDSchemeInvalid},
+		{"unknown scheme", "node-id-scheme(0x91):" + nodeIDStr, ErrNodeIDSchemeUnknown},
+		{"missing separator", nodeIDStr, errMissingTypedNodeIDSep},
+		{"empty", "", errMissingTypedNodeIDSep},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := TypedNodeIDFromString(tt.in)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+
+	_, err := TypedNodeIDFromString("ml-dsa-65:NodeID-notcb58")
+	require.Error(t, err) //nolint:forbidigo // cb58 errors are wrapped
+
+	_, err = TypedNodeID{}.MarshalText()
+	require.ErrorIs(t, err, ErrNodeIDSchemeInvalid)
+	b, err := TypedNodeID{}.AppendText([]byte("node="))
+	require.ErrorIs(t, err, ErrNodeIDSchemeInvalid)
+	require.Equal(t, []byte("node="), b, "a failed append must return the input")
+	_, err = json.Marshal(TypedNodeID{Scheme: NodeIDScheme(0x91)})
+	require.ErrorIs(t, err, ErrNodeIDSchemeUnknown)
+}
+
 // checkSchemeAgainstProfile is the cross-axis gate the consensus
 // boundary applies: the presented NodeID's scheme MUST match the
 // chain's pinned ValidatorScheme unless the operator has explicitly
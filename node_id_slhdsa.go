@@ -0,0 +1,131 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// node_id_slhdsa.go — SLH-DSA (FIPS 205) NodeID schemes.
+//
+// SLH-DSA is the stateless hash-based signature standard. Its security rests
+// only on the hash function, so governance validators can use it as a
+// conservative hedge against a break of the lattice assumptions behind
+// ML-DSA. Each of the twelve FIPS 205 parameter sets gets its own scheme
+// byte in the 0x50 block, in FIPS 205 Table 2 order, so a NodeID commits
+// to the exact parameter set a validator signs with.
+//
+// Derivation is the NODE_ID_V1 SHAKE256-384 framing documented on
+// DeriveMLDSA; the only addition is that the public key must have the
+// exact length of its parameter set.
+
+package ids
+
+import "fmt"
+
+// SLH-DSA NodeID schemes, one per FIPS 205 parameter set. "s" sets have
+// small signatures, "f" sets sign fast; the number is the security level
+// in bits.
+const (
+	NodeIDSchemeSLHDSASHA2128s  NodeIDScheme = 0x50
+	NodeIDSchemeSLHDSASHAKE128s NodeIDScheme = 0x51
+	NodeIDSchemeSLHDSASHA2128f  NodeIDScheme = 0x52
+	NodeIDSchemeSLHDSASHAKE128f NodeIDScheme = 0x53
+	NodeIDSchemeSLHDSASHA2192s  NodeIDScheme = 0x54
+	NodeIDSchemeSLHDSASHAKE192s NodeIDScheme = 0x55
+	NodeIDSchemeSLHDSASHA2192f  NodeIDScheme = 0x56
+	NodeIDSchemeSLHDSASHAKE192f NodeIDScheme = 0x57
+	NodeIDSchemeSLHDSASHA2256s  NodeIDScheme = 0x58
+	NodeIDSchemeSLHDSASHAKE256s NodeIDScheme = 0x59
+	NodeIDSchemeSLHDSASHA2256f  NodeIDScheme = 0x5a
+	NodeIDSchemeSLHDSASHAKE256f NodeIDScheme = 0x5b
+)
+
+// slhDSAParams lists every SLH-DSA scheme with its canonical name and
+// public key length (2n bytes: 32, 48 or 64 for security categories 1, 3
+// and 5).
+var slhDSAParams = []struct {
+	scheme    NodeIDScheme
+	name      string
+	pubKeyLen int
+}{
+	{NodeIDSchemeSLHDSASHA2128s, "slh-dsa-sha2-128s", 32},
+	{NodeIDSchemeSLHDSASHAKE128s, "slh-dsa-shake-128s", 32},
+	{NodeIDSchemeSLHDSASHA2128f, "slh-dsa-sha2-128f", 32},
+	{NodeIDSchemeSLHDSASHAKE128f, "slh-dsa-shake-128f", 32},
+	{NodeIDSchemeSLHDSASHA2192s, "slh-dsa-sha2-192s", 48},
+	{NodeIDSchemeSLHDSASHAKE192s, "slh-dsa-shake-192s", 48},
+	{NodeIDSchemeSLHDSASHA2192f, "slh-dsa-sha2-192f", 48},
+	{NodeIDSchemeSLHDSASHAKE192f, "slh-dsa-shake-192f", 48},
+	{NodeIDSchemeSLHDSASHA2256s, "slh-dsa-sha2-256s", 64},
+	{NodeIDSchemeSLHDSASHAKE256s, "slh-dsa-shake-256s", 64},
+	{NodeIDSchemeSLHDSASHA2256f, "slh-dsa-sha2-256f", 64},
+	{NodeIDSchemeSLHDSASHAKE256f, "slh-dsa-shake-256f", 64},
+}
+
+func init() {
+	for _, p := range slhDSAParams {
+		err := RegisterNodeIDScheme(NodeIDSchemeDescriptor{
+			Scheme: p.scheme,
+			Name:   p.name,
+			Class:  NodeIDSchemeClassPostQuantum,
+			Derive: deriveSLHDSA,
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// SLHDSAPublicKeyLen returns the public key length of the SLH-DSA scheme
+// [s], or 0 if [s] is not an SLH-DSA scheme.
+func SLHDSAPublicKeyLen(s NodeIDScheme) int {
+	for _, p := range slhDSAParams {
+		if p.scheme == s {
+			return p.pubKeyLen
+		}
+	}
+	return 0
+}
+
+// DeriveSLHDSA returns the 48-byte SHAKE256-384 digest and matching 20-byte
+// NodeID for an SLH-DSA public key under the supplied chain id. The
+// derivation is the one documented on DeriveMLDSA.
+//
+// scheme MUST be one of the SLH-DSA schemes and pubKey MUST be exactly
+// SLHDSAPublicKeyLen(scheme) bytes; anything else is rejected with
+// ErrNodeIDSchemeInvalid.
+func (s NodeIDScheme) DeriveSLHDSA(chainID ID, pubKey []byte) (NodeID, FullDigest, error) {
+	if SLHDSAPublicKeyLen(s) == 0 {
+		return EmptyNodeID, FullDigest{}, fmt.Errorf("%w: scheme=%s is not SLH-DSA",
+			ErrNodeIDSchemeInvalid, s.String())
+	}
+	return s.Derive(chainID, pubKey)
+}
+
+// deriveSLHDSA is the NodeIDDeriveFunc registered for every SLH-DSA scheme.
+func deriveSLHDSA(s NodeIDScheme, chainID ID, pubKey []byte) (NodeID, FullDigest, error) {
+	if expected := SLHDSAPublicKeyLen(s); len(pubKey) != expected {
+		return EmptyNodeID, FullDigest{}, fmt.Errorf("%w: %s public key is %d bytes, want %d",
+			ErrNodeIDSchemeInvalid, s.String(), len(pubKey), expected)
+	}
+	return deriveNodeIDV1(s, chainID, pubKey)
+}
+
+// TypedNodeIDFromSLHDSA produces a strict-PQ TypedNodeID from an SLH-DSA
+// public key under the supplied chain id. See DeriveSLHDSA for the
+// accepted schemes and key lengths.
+//
+// As with TypedNodeIDFromMLDSA, callers that bind validator identity into
+// a transcript MUST use the returned FullDigest, not the 20-byte NodeID
+// alone.
+func TypedNodeIDFromSLHDSA(
+	scheme NodeIDScheme,
+	chainID ID,
+	pubKey []byte,
+) (TypedNodeID, FullDigest, error) {
+	id, full, err := scheme.DeriveSLHDSA(chainID, pubKey)
+	if err != nil {
+		return TypedNodeID{}, FullDigest{}, err
+	}
+	t, err := NewTypedNodeID(scheme, id)
+	if err != nil {
+		return TypedNodeID{}, FullDigest{}, err
+	}
+	return t, full, nil
+}
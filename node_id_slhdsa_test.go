@@ -0,0 +1,90 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestNodeID_Derive_SLHDSA_KnownAnswers — pinned NODE_ID_V1 digests for
+// every SLH-DSA parameter set. Inputs: chainID = 0x00..0x1f and
+// pubkey[i] = i + scheme byte, of the parameter set's public key length.
+// The vectors were computed independently of this package with Python's
+// hashlib.shake_256 over the same left_encode framing.
+func TestNodeID_Derive_SLHDSA_KnownAnswers(t *testing.T) {
+	var chainID ID
+	for i := range chainID {
+		chainID[i] = byte(i)
+	}
+
+	tests := []struct {
+		scheme NodeIDScheme
+		name   string
+		digest string
+	}{
+		{NodeIDSchemeSLHDSASHA2128s, "slh-dsa-sha2-128s", "6309cb0b1e848bab2ed6d1a93e6a5bb0885a610499b85d3118600da0ee23f83bebcfa934cdec710bbe3055d6b5ff7097"},
+		{NodeIDSchemeSLHDSASHAKE128s, "slh-dsa-shake-128s", "f5cc7549080de705759d35cc7511b519cd1dcb4160d97cfc5ec92e1fecf0ea1fd5c91cb4ff8642124e647eca8d8b31af"},
+		{NodeIDSchemeSLHDSASHA2128f, "slh-dsa-sha2-128f", "5e07961babf61dd6882d0a8134c14684418af132141f725c9ad202a0d298feca5df5a49e24d2dd86e52947381c213ebf"},
+		{NodeIDSchemeSLHDSASHAKE128f, "slh-dsa-shake-128f", "0bb90792a4a9777f277e30d22c3c9daabb742488d5b1e821f1caafa05db248174171ec5163c5df90bc12226be74c2df4"},
+		{NodeIDSchemeSLHDSASHA2192s, "slh-dsa-sha2-192s", "2bb124b931a0c39deb05798df872ff6bee00e4bdb0f3ddd3b8d2a2cb9d34503b5527769286f6fa232471456ced537d14"},
+		{NodeIDSchemeSLHDSASHAKE192s, "slh-dsa-shake-192s", "3e061fcf31dc2935f8f3e9cd188c8266a26dbc39236e049ed61d416f66e9b1b83f8a319e9153f2a16db53cb5220a7165"},
+		{NodeIDSchemeSLHDSASHA2192f, "slh-dsa-sha2-192f", "8dddaf1ff3c33bb3d9eeddf5586d87a4ccc47020ceca6a8c850c97af34750e9fac72a247be7e93ac904e9b665a2035ec"},
+		{NodeIDSchemeSLHDSASHAKE192f, "slh-dsa-shake-192f", "44f5ff2a256211cf17245107359e9ad3d2b7c83707b3a4a36413503ee608f6b8ad6a71ab32e894b0d953b9689e6888e5"},
+		{NodeIDSchemeSLHDSASHA2256s, "slh-dsa-sha2-256s", "51d9f4a54aad19ba205b6728c445b102a12a3b979f8b13fea7307bfcfb48208c9700eb2b4da89f59d664f53820a76d9a"},
+		{NodeIDSchemeSLHDSASHAKE256s, "slh-dsa-shake-256s", "eb3bf1d97c970a20b2658a2e532ae5ae02dbf0c592bba79584b17a5a25e0752c43e6fdb0f0790e12b9169d9c392cbebb"},
+		{NodeIDSchemeSLHDSASHA2256f, "slh-dsa-sha2-256f", "ee99d0577ab7a969d213a2360b4e8f1028fbc80b46dd19d84ee60202ac37657a205b857bab76888ed33b498a5271ad4f"},
+		{NodeIDSchemeSLHDSASHAKE256f, "slh-dsa-shake-256f", "c8eb49115918f091f89f30e68d517c806b3e753981dd403e1dbe6de7c2a35ffac6d073e9e1408dbe7caa05cd32e48172"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			require.Equal(tt.name, tt.scheme.String())
+			require.True(tt.scheme.IsPostQuantum())
+
+			pubKey := make([]byte, SLHDSAPublicKeyLen(tt.scheme))
+			for i := range pubKey {
+				pubKey[i] = byte(i) + byte(tt.scheme)
+			}
+
+			typed, full, err := TypedNodeIDFromSLHDSA(tt.scheme, chainID, pubKey)
+			require.NoError(err)
+			require.Equal(tt.digest, hex.EncodeToString(full[:]))
+			require.Equal(tt.scheme, typed.Scheme)
+			require.Equal(full[:NodeIDLen], typed.NodeID[:])
+
+			parsed, err := ParseTypedNodeID(typed.Bytes())
+			require.NoError(err)
+			require.Equal(typed, parsed)
+		})
+	}
+}
+
+// TestNodeID_Derive_SLHDSA_Rejects — DeriveSLHDSA refuses schemes outside
+// the SLH-DSA block and public keys of the wrong length for the parameter
+// set.
+func TestNodeID_Derive_SLHDSA_Rejects(t *testing.T) {
+	require := require.New(t)
+
+	_, _, err := NodeIDSchemeMLDSA65.DeriveSLHDSA(ID{}, make([]byte, 32))
+	require.ErrorIs(err, ErrNodeIDSchemeInvalid)
+	_, _, err = TypedNodeIDFromSLHDSA(NodeIDSchemeSecp256k1, ID{}, make([]byte, 32))
+	require.ErrorIs(err, ErrNodeIDSchemeInvalid)
+
+	for _, n := range []int{0, 31, 33, 48} {
+		_, _, err = NodeIDSchemeSLHDSASHAKE128s.DeriveSLHDSA(ID{}, make([]byte, n))
+		require.ErrorIs(err, ErrNodeIDSchemeInvalid, "len=%d", n)
+	}
+
+	// A key valid for two parameter sets yields distinct NodeIDs.
+	pubKey := make([]byte, 32)
+	a, _, err := NodeIDSchemeSLHDSASHA2128s.DeriveSLHDSA(ID{}, pubKey)
+	require.NoError(err)
+	b, _, err := NodeIDSchemeSLHDSASHAKE128s.DeriveSLHDSA(ID{}, pubKey)
+	require.NoError(err)
+	require.NotEqual(a, b)
+}
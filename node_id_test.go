@@ -154,6 +154,33 @@ func TestNodeIDUnmarshalJSON(t *testing.T) {
 	}
 }
 
+func TestNodeIDUnmarshalText(t *testing.T) {
+	id := NodeID{'a', 'v', 'a', ' ', 'l', 'a', 'b', 's'}
+	tests := []struct {
+		label       string
+		in          string
+		out         NodeID
+		expectedErr error
+	}{
+		{"unquoted", "NodeID-9tLMkeWFhWXd8QZc4rSiS5meuVXF5kRsz", id, nil},
+		{"quoted", `"NodeID-9tLMkeWFhWXd8QZc4rSiS5meuVXF5kRsz"`, id, nil},
+		{"null", "null", NodeID{24}, nil},
+		{"empty", "", NodeID{24}, errShortNodeID},
+		{"empty quoted", `""`, NodeID{24}, errShortNodeID},
+		{"missing end quote", `"NodeID-9tLMkeWFhWXd8QZc4rSiS5meuVXF5kRsz`, NodeID{24}, errMissingQuotes},
+	}
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			require := require.New(t)
+
+			foo := NodeID{24}
+			err := foo.UnmarshalText([]byte(tt.in))
+			require.ErrorIs(err, tt.expectedErr)
+			require.Equal(tt.out, foo)
+		})
+	}
+}
+
 func TestNodeIDString(t *testing.T) {
 	tests := []struct {
 		label    string
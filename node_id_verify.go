@@ -0,0 +1,80 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+)
+
+// ErrNodeIDMismatch — the presented key material does not derive the
+// claimed NodeID (or FullDigest).
+var ErrNodeIDMismatch = errors.New("ids: NodeID does not match presented key")
+
+// VerifyTypedNodeID re-derives the NodeID of [pubKey] under [chainID] with
+// the derivation registered for [t]'s scheme (DeriveMLDSA for ML-DSA,
+// DeriveSLHDSA for SLH-DSA, ...) and compares it to [t] in constant time.
+//
+// It returns ErrNodeIDSchemeInvalid or ErrNodeIDSchemeUnknown if the
+// scheme is dead or has no public-key derivation (secp256k1 NodeIDs are
+// verified with VerifyTypedNodeIDCert), any derivation error such as a
+// malformed key, and ErrNodeIDMismatch if the NodeIDs differ.
+func VerifyTypedNodeID(t TypedNodeID, chainID ID, pubKey []byte) error {
+	_, err := verifyDerivedNodeID(t, chainID, pubKey)
+	return err
+}
+
+// VerifyTypedNodeIDDigest is VerifyTypedNodeID that additionally requires
+// the full 48-byte derivation digest to equal [digest]. Use it where the
+// FullDigest, not only its 20-byte prefix, is bound into a transcript or a
+// ValidatorSetCommitment.
+func VerifyTypedNodeIDDigest(t TypedNodeID, digest FullDigest, chainID ID, pubKey []byte) error {
+	derived, err := verifyDerivedNodeID(t, chainID, pubKey)
+	if err != nil {
+		return err
+	}
+	if !derived.Equal(digest) {
+		return fmt.Errorf("%w: FullDigest of %s", ErrNodeIDMismatch, t)
+	}
+	return nil
+}
+
+// VerifyTypedNodeIDCert checks a classical-compat [t] against the staking
+// certificate it claims to come from, using NodeIDFromCert and a
+// constant-time comparison. Only NodeIDSchemeSecp256k1 NodeIDs are derived
+// from certificates; any other scheme is refused with
+// ErrNodeIDSchemeInvalid.
+func VerifyTypedNodeIDCert(t TypedNodeID, cert *Certificate) error {
+	if err := t.Scheme.checkKnown(); err != nil {
+		return err
+	}
+	if t.Scheme != NodeIDSchemeSecp256k1 {
+		return fmt.Errorf("%w: scheme=%s is not derived from a certificate",
+			ErrNodeIDSchemeInvalid, t.Scheme)
+	}
+	if cert == nil || len(cert.Raw) == 0 {
+		return fmt.Errorf("%w: empty certificate", ErrNodeIDSchemeInvalid)
+	}
+	derived := NodeIDFromCert(cert)
+	if subtle.ConstantTimeCompare(derived[:], t.NodeID[:]) != 1 {
+		return fmt.Errorf("%w: %s", ErrNodeIDMismatch, t)
+	}
+	return nil
+}
+
+// verifyDerivedNodeID returns the derived FullDigest if it matches [t].
+func verifyDerivedNodeID(t TypedNodeID, chainID ID, pubKey []byte) (FullDigest, error) {
+	if err := t.Scheme.checkKnown(); err != nil {
+		return FullDigest{}, err
+	}
+	id, digest, err := t.Scheme.Derive(chainID, pubKey)
+	if err != nil {
+		return FullDigest{}, err
+	}
+	if subtle.ConstantTimeCompare(id[:], t.NodeID[:]) != 1 {
+		return FullDigest{}, fmt.Errorf("%w: %s", ErrNodeIDMismatch, t)
+	}
+	return digest, nil
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyTypedNodeID(t *testing.T) {
+	chainID := ID{0xc0}
+	mldsaKey := []byte("ml-dsa-87-public-key")
+	slhKey := make([]byte, SLHDSAPublicKeyLen(NodeIDSchemeSLHDSASHA2192f))
+
+	mldsa, mldsaDigest, err := TypedNodeIDFromMLDSA(NodeIDSchemeMLDSA87, chainID, mldsaKey)
+	require.NoError(t, err)
+	slhdsa, slhdsaDigest, err := TypedNodeIDFromSLHDSA(NodeIDSchemeSLHDSASHA2192f, chainID, slhKey)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		t           TypedNodeID
+		digest      FullDigest
+		chainID     ID
+		pubKey      []byte
+		expectedErr error
+	}{
+		{
+			name:    "ML-DSA",
+			t:       mldsa,
+			digest:  mldsaDigest,
+			chainID: chainID,
+			pubKey:  mldsaKey,
+		},
+		{
+			name:    "SLH-DSA",
+			t:       slhdsa,
+			digest:  slhdsaDigest,
+			chainID: chainID,
+			pubKey:  slhKey,
+		},
+		{
+			name:        "other key",
+			t:           mldsa,
+			chainID:     chainID,
+			pubKey:      []byte("another-public-key"),
+			expectedErr: ErrNodeIDMismatch,
+		},
+		{
+			name:        "other chain",
+			t:           mldsa,
+			chainID:     ID{0xc1},
+			pubKey:      mldsaKey,
+			expectedErr: ErrNodeIDMismatch,
+		},
+		{
+			name:        "scheme swapped",
+			t:           TypedNodeID{Scheme: NodeIDSchemeMLDSA65, NodeID: mldsa.NodeID},
+			chainID:     chainID,
+			pubKey:      mldsaKey,
+			expectedErr: ErrNodeIDMismatch,
+		},
+		{
+			name:        "wrong SLH-DSA key length",
+			t:           slhdsa,
+			chainID:     chainID,
+			pubKey:      mldsaKey,
+			expectedErr: ErrNodeIDSchemeInvalid,
+		},
+		{
+			name:        "classical",
+			t:           TypedNodeID{Scheme: NodeIDSchemeSecp256k1},
+			chainID:     chainID,
+			pubKey:      mldsaKey,
+			expectedErr: ErrNodeIDSchemeInvalid,
+		},
+		{
+			name:        "invalid scheme",
+			t:           TypedNodeID{NodeID: mldsa.NodeID},
+			chainID:     chainID,
+			pubKey:      mldsaKey,
+			expectedErr: ErrNodeIDSchemeInvalid,
+		},
+		{
+			name:        "unknown scheme",
+			t:           TypedNodeID{Scheme: 0x44, NodeID: mldsa.NodeID},
+			chainID:     chainID,
+			pubKey:      mldsaKey,
+			expectedErr: ErrNodeIDSchemeUnknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			require.ErrorIs(VerifyTypedNodeID(tt.t, tt.chainID, tt.pubKey), tt.expectedErr)
+			if tt.expectedErr == nil {
+				require.NoError(VerifyTypedNodeIDDigest(tt.t, tt.digest, tt.chainID, tt.pubKey))
+			}
+		})
+	}
+}
+
+func TestVerifyTypedNodeIDDigest_Mismatch(t *testing.T) {
+	require := require.New(t)
+
+	pubKey := []byte("ml-dsa-65-public-key")
+	typed, digest, err := TypedNodeIDFromMLDSA(NodeIDSchemeMLDSA65, ID{}, pubKey)
+	require.NoError(err)
+
+	// Same 20-byte prefix, different tail: only the digest check catches it.
+	digest[FullDigestLen-1] ^= 0x01
+	require.NoError(VerifyTypedNodeID(typed, ID{}, pubKey))
+	require.ErrorIs(VerifyTypedNodeIDDigest(typed, digest, ID{}, pubKey), ErrNodeIDMismatch)
+}
+
+func TestVerifyTypedNodeIDCert(t *testing.T) {
+	require := require.New(t)
+
+	cert := &Certificate{Raw: []byte("der-encoded-cert-fixture")}
+	typed := TypedNodeIDFromCert(cert)
+	require.NoError(VerifyTypedNodeIDCert(typed, cert))
+
+	other := &Certificate{Raw: []byte("another-cert")}
+	require.ErrorIs(VerifyTypedNodeIDCert(typed, other), ErrNodeIDMismatch)
+	require.ErrorIs(VerifyTypedNodeIDCert(typed, nil), ErrNodeIDSchemeInvalid)
+	require.ErrorIs(VerifyTypedNodeIDCert(typed, &Certificate{}), ErrNodeIDSchemeInvalid)
+
+	pq := TypedNodeID{Scheme: NodeIDSchemeMLDSA65, NodeID: typed.NodeID}
+	require.ErrorIs(VerifyTypedNodeIDCert(pq, cert), ErrNodeIDSchemeInvalid)
+	unknown := TypedNodeID{Scheme: 0x91, NodeID: typed.NodeID}
+	require.ErrorIs(VerifyTypedNodeIDCert(unknown, cert), ErrNodeIDSchemeUnknown)
+}
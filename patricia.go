@@ -0,0 +1,188 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import "iter"
+
+// PatriciaTrie is a binary, path-compressed trie mapping IDs to values. The
+// zero value is an empty trie ready to use. It is not safe for concurrent
+// use.
+//
+// Keys are decomposed with the bit indexing documented on EqualSubset and
+// used by ID.Bit: bit 0 is the least significant bit of byte 0, bit 8 the
+// least significant bit of byte 1, and so on. Every branch in the trie
+// splits on one such bit, with the 0 child before the 1 child, so iteration
+// visits keys in "bit order" — ordered by bit 0 first, then bit 1, ... —
+// which is the order a snowball-style binary decomposition walks them in.
+// Note that this is not the order of ID.Compare.
+type PatriciaTrie[V any] struct {
+	root *patriciaNode[V]
+	size int
+}
+
+type patriciaNode[V any] struct {
+	// key holds the bits shared by every key below this node. For a branch
+	// only bits [0, bits) are meaningful; for a leaf it is the full key.
+	key ID
+	// bits is the index of the bit this branch splits on, or NumBits for a
+	// leaf.
+	bits     int
+	value    V
+	children [2]*patriciaNode[V]
+}
+
+func (n *patriciaNode[V]) isLeaf() bool {
+	return n.bits == NumBits
+}
+
+// Len returns the number of keys in the trie.
+func (t *PatriciaTrie[V]) Len() int {
+	return t.size
+}
+
+// Put maps [key] to [value], returning true if an existing value was
+// replaced.
+func (t *PatriciaTrie[V]) Put(key ID, value V) bool {
+	slot := &t.root
+	depth := 0
+	for {
+		n := *slot
+		if n == nil {
+			*slot = &patriciaNode[V]{key: key, bits: NumBits, value: value}
+			t.size++
+			return false
+		}
+
+		if diff, ok := FirstDifferenceSubset(depth, n.bits, key, n.key); ok {
+			// [key] leaves this node's shared prefix at bit [diff]; insert a
+			// branch on that bit above [n].
+			branch := &patriciaNode[V]{key: key, bits: diff}
+			branch.children[key.Bit(uint(diff))] = &patriciaNode[V]{key: key, bits: NumBits, value: value}
+			branch.children[n.key.Bit(uint(diff))] = n
+			*slot = branch
+			t.size++
+			return false
+		}
+
+		if n.isLeaf() {
+			n.value = value
+			return true
+		}
+		slot = &n.children[key.Bit(uint(n.bits))]
+		depth = n.bits + 1
+	}
+}
+
+// Get returns the value mapped to [key].
+func (t *PatriciaTrie[V]) Get(key ID) (V, bool) {
+	n := t.root
+	for n != nil && !n.isLeaf() {
+		n = n.children[key.Bit(uint(n.bits))]
+	}
+	if n == nil || n.key != key {
+		var zero V
+		return zero, false
+	}
+	return n.value, true
+}
+
+// Delete removes [key] from the trie, returning true if it was present.
+func (t *PatriciaTrie[V]) Delete(key ID) bool {
+	var parentSlot **patriciaNode[V]
+	slot := &t.root
+	for n := *slot; n != nil; n = *slot {
+		if !n.isLeaf() {
+			parentSlot = slot
+			slot = &n.children[key.Bit(uint(n.bits))]
+			continue
+		}
+
+		if n.key != key {
+			return false
+		}
+		if parentSlot == nil {
+			t.root = nil
+		} else {
+			// A branch always has two children; with one removed, the
+			// sibling takes the branch's place.
+			parent := *parentSlot
+			*parentSlot = parent.children[1-key.Bit(uint(parent.bits))]
+		}
+		t.size--
+		return true
+	}
+	return false
+}
+
+// LongestCommonPrefix returns the stored key that shares the longest prefix
+// with [key], its value, and the length of the shared prefix in bits. If
+// several keys share the longest prefix, the first in bit order is
+// returned; an exact match reports NumBits. Returns false if the trie is
+// empty.
+func (t *PatriciaTrie[V]) LongestCommonPrefix(key ID) (ID, V, int, bool) {
+	n := t.root
+	depth := 0
+	for n != nil {
+		if diff, ok := FirstDifferenceSubset(depth, n.bits, key, n.key); ok {
+			// Every key below [n] shares exactly [diff] bits with [key].
+			leaf := n.first()
+			return leaf.key, leaf.value, diff, true
+		}
+		if n.isLeaf() {
+			return n.key, n.value, NumBits, true
+		}
+		depth = n.bits + 1
+		n = n.children[key.Bit(uint(n.bits))]
+	}
+
+	var zero V
+	return Empty, zero, 0, false
+}
+
+// All returns an iterator over every key and value in bit order.
+func (t *PatriciaTrie[V]) All() iter.Seq2[ID, V] {
+	return func(yield func(ID, V) bool) {
+		t.root.walk(yield)
+	}
+}
+
+// WithPrefix returns an iterator, in bit order, over the keys whose first
+// [prefixLen] bits equal those of [prefix]. prefixLen is clamped to
+// [0, NumBits].
+func (t *PatriciaTrie[V]) WithPrefix(prefix ID, prefixLen int) iter.Seq2[ID, V] {
+	prefixLen = min(max(prefixLen, 0), NumBits)
+	return func(yield func(ID, V) bool) {
+		n := t.root
+		for n != nil && n.bits < prefixLen {
+			if !EqualSubset(0, n.bits, prefix, n.key) {
+				return
+			}
+			n = n.children[prefix.Bit(uint(n.bits))]
+		}
+		if n == nil || !EqualSubset(0, prefixLen, prefix, n.key) {
+			return
+		}
+		n.walk(yield)
+	}
+}
+
+// first returns the first leaf below [n] in bit order.
+func (n *patriciaNode[V]) first() *patriciaNode[V] {
+	for !n.isLeaf() {
+		n = n.children[0]
+	}
+	return n
+}
+
+// walk yields every leaf below [n] in bit order, returning false if [yield]
+// asked to stop.
+func (n *patriciaNode[V]) walk(yield func(ID, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	if n.isLeaf() {
+		return yield(n.key, n.value)
+	}
+	return n.children[0].walk(yield) && n.children[1].walk(yield)
+}
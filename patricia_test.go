@@ -0,0 +1,191 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// bitOrder compares IDs the way PatriciaTrie iterates them: by bit 0 first.
+func bitOrder(a, b ID) int {
+	return strings.Compare(BitString(a), BitString(b))
+}
+
+// commonPrefixLen returns the number of leading bits a and b share.
+func commonPrefixLen(a, b ID) int {
+	if diff, ok := FirstDifferenceSubset(0, NumBits, a, b); ok {
+		return diff
+	}
+	return NumBits
+}
+
+func TestPatriciaTrieEmpty(t *testing.T) {
+	require := require.New(t)
+
+	var trie PatriciaTrie[int]
+	require.Zero(trie.Len())
+
+	_, ok := trie.Get(Empty)
+	require.False(ok)
+	require.False(trie.Delete(Empty))
+
+	_, _, _, ok = trie.LongestCommonPrefix(Empty)
+	require.False(ok)
+
+	for range trie.All() {
+		require.FailNow("empty trie yielded a key")
+	}
+}
+
+func TestPatriciaTriePutGetDelete(t *testing.T) {
+	require := require.New(t)
+
+	var trie PatriciaTrie[string]
+	require.False(trie.Put(ID{0x01}, "a"))
+	require.False(trie.Put(ID{0x03}, "b"))
+	require.False(trie.Put(ID{0x00, 0x01}, "c"))
+	require.True(trie.Put(ID{0x03}, "B"))
+	require.Equal(3, trie.Len())
+
+	v, ok := trie.Get(ID{0x03})
+	require.True(ok)
+	require.Equal("B", v)
+
+	_, ok = trie.Get(ID{0x02})
+	require.False(ok)
+
+	require.True(trie.Delete(ID{0x01}))
+	require.False(trie.Delete(ID{0x01}))
+	require.Equal(2, trie.Len())
+
+	v, ok = trie.Get(ID{0x00, 0x01})
+	require.True(ok)
+	require.Equal("c", v)
+
+	require.True(trie.Delete(ID{0x03}))
+	require.True(trie.Delete(ID{0x00, 0x01}))
+	require.Zero(trie.Len())
+}
+
+func TestPatriciaTrieLongestCommonPrefix(t *testing.T) {
+	require := require.New(t)
+
+	var trie PatriciaTrie[int]
+	trie.Put(ID{0x01}, 1) // bits: 1000 0000 ...
+	trie.Put(ID{0x05}, 5) // bits: 1010 0000 ...
+
+	key, v, n, ok := trie.LongestCommonPrefix(ID{0x05})
+	require.True(ok)
+	require.Equal(ID{0x05}, key)
+	require.Equal(5, v)
+	require.Equal(NumBits, n)
+
+	key, v, n, ok = trie.LongestCommonPrefix(ID{0x07}) // 1110 0000 ...
+	require.True(ok)
+	require.Equal(ID{0x01}, key)
+	require.Equal(1, v)
+	require.Equal(1, n)
+
+	key, _, n, ok = trie.LongestCommonPrefix(ID{0x0d}) // 1011 0000 ...
+	require.True(ok)
+	require.Equal(ID{0x05}, key)
+	require.Equal(3, n)
+
+	// Bit 0 differs from every key: the first key in bit order is returned.
+	key, _, n, ok = trie.LongestCommonPrefix(ID{0x00})
+	require.True(ok)
+	require.Equal(ID{0x01}, key)
+	require.Zero(n)
+}
+
+func TestPatriciaTrieRandomized(t *testing.T) {
+	require := require.New(t)
+
+	rng := rand.New(rand.NewSource(1337)) //#nosec G404
+	randomID := func() ID {
+		var id ID
+		// Keep most bytes fixed so keys share long prefixes.
+		id[0] = byte(rng.Intn(4))
+		id[rng.Intn(IDLen)] = byte(rng.Intn(256))
+		return id
+	}
+
+	var trie PatriciaTrie[int]
+	expected := make(map[ID]int)
+	for i := 0; i < 2000; i++ {
+		id := randomID()
+		switch rng.Intn(3) {
+		case 0:
+			_, existed := expected[id]
+			require.Equal(existed, trie.Delete(id))
+			delete(expected, id)
+		default:
+			_, existed := expected[id]
+			require.Equal(existed, trie.Put(id, i))
+			expected[id] = i
+		}
+		require.Equal(len(expected), trie.Len())
+	}
+
+	keys := make([]ID, 0, len(expected))
+	for id := range expected {
+		keys = append(keys, id)
+		v, ok := trie.Get(id)
+		require.True(ok)
+		require.Equal(expected[id], v)
+	}
+	slices.SortFunc(keys, bitOrder)
+
+	var iterated []ID
+	for id, v := range trie.All() {
+		require.Equal(expected[id], v)
+		iterated = append(iterated, id)
+	}
+	require.Equal(keys, iterated)
+
+	for i := 0; i < 200; i++ {
+		query := randomID()
+		_, _, n, ok := trie.LongestCommonPrefix(query)
+		require.True(ok)
+		best := 0
+		for _, id := range keys {
+			best = max(best, commonPrefixLen(query, id))
+		}
+		require.Equal(best, n)
+
+		prefixLen := rng.Intn(24)
+		var want []ID
+		for _, id := range keys {
+			if EqualSubset(0, prefixLen, query, id) {
+				want = append(want, id)
+			}
+		}
+		var got []ID
+		for id := range trie.WithPrefix(query, prefixLen) {
+			got = append(got, id)
+		}
+		require.Equal(want, got, "prefixLen=%d", prefixLen)
+	}
+}
+
+func TestPatriciaTrieIterationStops(t *testing.T) {
+	var trie PatriciaTrie[int]
+	for i := 0; i < 10; i++ {
+		trie.Put(ID{byte(i)}, i)
+	}
+
+	count := 0
+	for range trie.All() {
+		count++
+		if count == 3 {
+			break
+		}
+	}
+	require.Equal(t, 3, count)
+}
@@ -3,6 +3,37 @@
 
 package ids
 
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	// requestIDCodecVersion is the leading byte of the RequestID binary
+	// encoding. A layout change MUST bump it; decoders refuse any version
+	// they do not know.
+	requestIDCodecVersion = 0
+
+	// RequestIDLen is the byte length of the binary encoding of a RequestID:
+	//
+	//	version            1 byte  (requestIDCodecVersion)
+	//	NodeID             20 bytes
+	//	SourceChainID      32 bytes
+	//	DestinationChainID 32 bytes
+	//	RequestID          4 bytes, big-endian
+	//	Op                 1 byte
+	RequestIDLen = 1 + NodeIDLen + IDLen + IDLen + uint32Len + 1
+)
+
+var (
+	// ErrRequestIDLen — binary input was not exactly RequestIDLen bytes.
+	ErrRequestIDLen = errors.New("ids: RequestID length mismatch")
+
+	// ErrRequestIDVersion — binary input names an unknown codec version.
+	ErrRequestIDVersion = errors.New("ids: RequestID codec version is unknown")
+)
+
 // RequestID is a unique identifier for an in-flight request pending a response.
 type RequestID struct {
 	// The node this request came from
@@ -16,3 +47,39 @@ type RequestID struct {
 	// The message opcode
 	Op byte
 }
+
+// MarshalBinary returns the RequestIDLen-byte encoding documented on
+// RequestIDLen.
+func (r RequestID) MarshalBinary() ([]byte, error) {
+	return r.AppendBinary(make([]byte, 0, RequestIDLen))
+}
+
+// AppendBinary appends the binary encoding of this request ID to [b].
+func (r RequestID) AppendBinary(b []byte) ([]byte, error) {
+	b = append(b, requestIDCodecVersion)
+	b = append(b, r.NodeID[:]...)
+	b = append(b, r.SourceChainID[:]...)
+	b = append(b, r.DestinationChainID[:]...)
+	b = binary.BigEndian.AppendUint32(b, r.RequestID)
+	return append(b, r.Op), nil
+}
+
+// UnmarshalBinary is the inverse of MarshalBinary.
+func (r *RequestID) UnmarshalBinary(b []byte) error {
+	if len(b) != RequestIDLen {
+		return fmt.Errorf("%w: got %d bytes, want %d", ErrRequestIDLen, len(b), RequestIDLen)
+	}
+	if b[0] != requestIDCodecVersion {
+		return fmt.Errorf("%w: %d", ErrRequestIDVersion, b[0])
+	}
+	b = b[1:]
+
+	var parsed RequestID
+	b = b[copy(parsed.NodeID[:], b):]
+	b = b[copy(parsed.SourceChainID[:], b):]
+	b = b[copy(parsed.DestinationChainID[:], b):]
+	parsed.RequestID = binary.BigEndian.Uint32(b)
+	parsed.Op = b[uint32Len]
+	*r = parsed
+	return nil
+}
@@ -0,0 +1,239 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"bytes"
+	"encoding/json"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// minSetSize is the initial capacity of a Set allocated lazily by Add.
+const minSetSize = 16
+
+// Set is an unordered collection of unique elements. The zero value is an
+// empty set ready to use.
+//
+// Set iteration over a map is randomised by the runtime; callers that need
+// a stable order (logs, hashes, wire encodings) use SortedList or Sorted,
+// which order elements through their Sortable Compare method. JSON encoding
+// is always a sorted list so two equal sets marshal to identical bytes.
+type Set[T comparable] map[T]struct{}
+
+// NewSet returns a new empty set with space preallocated for [size]
+// elements.
+func NewSet[T comparable](size int) Set[T] {
+	if size < 0 {
+		return Set[T]{}
+	}
+	return make(Set[T], size)
+}
+
+// SetOf returns a set initialized with [elts].
+func SetOf[T comparable](elts ...T) Set[T] {
+	s := NewSet[T](len(elts))
+	s.Add(elts...)
+	return s
+}
+
+func (s *Set[T]) resize(size int) {
+	if *s == nil {
+		*s = make(Set[T], max(size, minSetSize))
+	}
+}
+
+// Add all the elements to this set. If an element is already in the set,
+// nothing happens.
+func (s *Set[T]) Add(elts ...T) {
+	s.resize(2 * len(elts))
+	for _, elt := range elts {
+		(*s)[elt] = struct{}{}
+	}
+}
+
+// Union adds all the elements from the provided set to this set.
+func (s *Set[T]) Union(set Set[T]) {
+	s.resize(2 * set.Len())
+	for elt := range set {
+		(*s)[elt] = struct{}{}
+	}
+}
+
+// Difference removes all the elements in [set] from this set.
+func (s *Set[T]) Difference(set Set[T]) {
+	for elt := range set {
+		delete(*s, elt)
+	}
+}
+
+// Intersection removes all the elements from this set that are not in
+// [set].
+func (s *Set[T]) Intersection(set Set[T]) {
+	for elt := range *s {
+		if !set.Contains(elt) {
+			delete(*s, elt)
+		}
+	}
+}
+
+// Contains returns true iff the set contains this element.
+func (s Set[T]) Contains(elt T) bool {
+	_, contains := s[elt]
+	return contains
+}
+
+// Overlaps returns true if the intersection of the set is non-empty
+func (s Set[T]) Overlaps(big Set[T]) bool {
+	small := s
+	if small.Len() > big.Len() {
+		small, big = big, small
+	}
+
+	for elt := range small {
+		if _, ok := big[elt]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Len returns the number of elements in this set.
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// Remove all the given elements from this set. If an element isn't in the
+// set, it's ignored.
+func (s *Set[T]) Remove(elts ...T) {
+	for _, elt := range elts {
+		delete(*s, elt)
+	}
+}
+
+// Clear empties this set
+func (s *Set[T]) Clear() {
+	clear(*s)
+}
+
+// List converts this set into a list. The order is unspecified; use
+// SortedList when the order matters.
+func (s Set[T]) List() []T {
+	return slices.Collect(maps.Keys(s))
+}
+
+// Equals returns true if the sets contain the same elements
+func (s Set[T]) Equals(other Set[T]) bool {
+	return maps.Equal(s, other)
+}
+
+// Clone returns a copy of this set. The copy is never nil.
+func (s Set[T]) Clone() Set[T] {
+	c := NewSet[T](s.Len())
+	c.Union(s)
+	return c
+}
+
+// Peek returns an element. If the set is empty, returns false.
+func (s Set[T]) Peek() (T, bool) {
+	for elt := range s {
+		return elt, true
+	}
+	var zero T
+	return zero, false
+}
+
+// Pop removes and returns an element. If the set is empty, returns false.
+func (s *Set[T]) Pop() (T, bool) {
+	for elt := range *s {
+		delete(*s, elt)
+		return elt, true
+	}
+	var zero T
+	return zero, false
+}
+
+// All returns an iterator over the elements of this set in unspecified
+// order.
+func (s Set[T]) All() iter.Seq[T] {
+	return maps.Keys(s)
+}
+
+// SortedList returns the elements of [s] ordered by their Compare method.
+func SortedList[T interface {
+	comparable
+	Sortable[T]
+}](s Set[T]) []T {
+	list := s.List()
+	Sort(list)
+	return list
+}
+
+// Sorted returns an iterator over the elements of [s] ordered by their
+// Compare method. The set is snapshotted when iteration begins.
+func Sorted[T interface {
+	comparable
+	Sortable[T]
+}](s Set[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, elt := range SortedList(s) {
+			if !yield(elt) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalJSON encodes the set as a JSON list. Elements that implement
+// Sortable are ordered by Compare; any other element type is ordered by its
+// JSON encoding so the output is deterministic either way.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	elts := s.List()
+	var zero T
+	_, sortable := any(zero).(Sortable[T])
+	if sortable {
+		slices.SortFunc(elts, func(a, b T) int {
+			return any(a).(Sortable[T]).Compare(b)
+		})
+	}
+
+	eltBytes := make([][]byte, len(elts))
+	for i, elt := range elts {
+		b, err := json.Marshal(elt)
+		if err != nil {
+			return nil, err
+		}
+		eltBytes[i] = b
+	}
+	if !sortable {
+		slices.SortFunc(eltBytes, bytes.Compare)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, b := range eltBytes {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(b)
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON replaces the contents of the set with the elements of a
+// JSON list. "null" leaves the set unchanged.
+func (s *Set[T]) UnmarshalJSON(b []byte) error {
+	if string(b) == nullStr {
+		return nil
+	}
+	var elts []T
+	if err := json.Unmarshal(b, &elts); err != nil {
+		return err
+	}
+	s.Clear()
+	s.Add(elts...)
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetZeroValue(t *testing.T) {
+	require := require.New(t)
+
+	var s Set[ID]
+	require.Zero(s.Len())
+	require.False(s.Contains(Empty))
+
+	s.Add(Empty)
+	require.True(s.Contains(Empty))
+	require.Equal(1, s.Len())
+}
+
+func TestSetOperations(t *testing.T) {
+	require := require.New(t)
+
+	id1 := ID{1}
+	id2 := ID{2}
+	id3 := ID{3}
+
+	s := SetOf(id1, id2)
+	other := SetOf(id2, id3)
+	require.True(s.Overlaps(other))
+
+	union := s.Clone()
+	union.Union(other)
+	require.Equal(SetOf(id1, id2, id3), union)
+
+	intersection := s.Clone()
+	intersection.Intersection(other)
+	require.Equal(SetOf(id2), intersection)
+
+	difference := s.Clone()
+	difference.Difference(other)
+	require.Equal(SetOf(id1), difference)
+
+	s.Remove(id1, id3)
+	require.True(s.Equals(SetOf(id2)))
+
+	elt, ok := s.Pop()
+	require.True(ok)
+	require.Equal(id2, elt)
+	_, ok = s.Pop()
+	require.False(ok)
+	require.False(s.Overlaps(other))
+}
+
+func TestSetSorted(t *testing.T) {
+	require := require.New(t)
+
+	expected := []NodeID{{1}, {2}, {3}, {4}}
+	s := SetOf(expected[3], expected[1], expected[0], expected[2])
+
+	require.Equal(expected, SortedList(s))
+	require.Equal(expected, slices.Collect(Sorted(s)))
+	require.ElementsMatch(expected, slices.Collect(s.All()))
+}
+
+func TestSetJSON(t *testing.T) {
+	require := require.New(t)
+
+	s := SetOf(ID{2}, ID{1})
+	b, err := json.Marshal(s)
+	require.NoError(err)
+
+	expected, err := json.Marshal([]ID{{1}, {2}})
+	require.NoError(err)
+	require.Equal(expected, b)
+
+	var parsed Set[ID]
+	require.NoError(json.Unmarshal(b, &parsed))
+	require.Equal(s, parsed)
+
+	// Non-Sortable elements are ordered by their encoding.
+	b, err = json.Marshal(SetOf(3, 1, 2))
+	require.NoError(err)
+	require.Equal(`[1,2,3]`, string(b))
+
+	b, err = json.Marshal(Set[ShortID]{})
+	require.NoError(err)
+	require.Equal(`[]`, string(b))
+}
@@ -32,6 +32,11 @@ func ToShortID(bytes []byte) (ShortID, error) {
 
 // ShortFromString is the inverse of ShortID.String()
 func ShortFromString(idStr string) (ShortID, error) {
+	var id ShortID
+	if decodeCB58(id[:], idStr) {
+		return id, nil
+	}
+	// Slow path: reproduce the cb58 error for malformed input.
 	bytes, err := cb58.Decode(idStr)
 	if err != nil {
 		return ShortID{}, err
@@ -49,11 +54,10 @@ func ShortFromPrefixedString(idStr, prefix string) (ShortID, error) {
 }
 
 func (id ShortID) MarshalJSON() ([]byte, error) {
-	str, err := cb58.Encode(id[:])
-	if err != nil {
-		return nil, err
-	}
-	return []byte(`"` + str + `"`), nil
+	b := make([]byte, 0, cb58MaxLen+2)
+	b = append(b, '"')
+	b = id.AppendString(b)
+	return append(b, '"'), nil
 }
 
 func (id *ShortID) UnmarshalJSON(b []byte) error {
@@ -69,6 +73,9 @@ func (id *ShortID) UnmarshalJSON(b []byte) error {
 		return errMissingQuotes
 	}
 
+	if decodeCB58(id[:], str[1:lastIndex]) {
+		return nil
+	}
 	// Parse CB58 formatted string to bytes
 	bytes, err := cb58.Decode(str[1:lastIndex])
 	if err != nil {
@@ -78,8 +85,24 @@ func (id *ShortID) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// UnmarshalText decodes a CB58 string. It is the inverse of MarshalText, so
+// unlike UnmarshalJSON it accepts unquoted input, which is how the stdlib
+// passes map keys to a TextUnmarshaler. Quoted input, "null" and empty input
+// are handled as UnmarshalJSON handles them.
 func (id *ShortID) UnmarshalText(text []byte) error {
-	return id.UnmarshalJSON(text)
+	str := string(text)
+	if str == "" || str == nullStr || str[0] == '"' {
+		return id.UnmarshalJSON(text)
+	}
+	if decodeCB58(id[:], str) {
+		return nil
+	}
+	bytes, err := cb58.Decode(str)
+	if err != nil {
+		return fmt.Errorf("couldn't decode ID to bytes: %w", err)
+	}
+	*id, err = ToShortID(bytes)
+	return err
 }
 
 // Bytes returns the 20 byte hash as a slice. It is assumed this slice is not
@@ -94,10 +117,19 @@ func (id ShortID) Hex() string {
 }
 
 func (id ShortID) String() string {
-	// We assume that the maximum size of a byte slice that
-	// can be stringified is at least the length of an ID
-	str, _ := cb58.Encode(id.Bytes())
-	return str
+	if c := globalStringCache.Load(); c != nil {
+		return c.ShortID(id)
+	}
+
+	var buf [cb58MaxLen]byte
+	return string(appendCB58(buf[:0], id[:]))
+}
+
+// AppendString appends the String form of this id to [dst] without
+// allocating, provided [dst] has room for it. The output is identical to
+// String.
+func (id ShortID) AppendString(dst []byte) []byte {
+	return appendCB58(dst, id[:])
 }
 
 // PrefixedString returns the String representation with a prefix added
@@ -106,7 +138,33 @@ func (id ShortID) PrefixedString(prefix string) string {
 }
 
 func (id ShortID) MarshalText() ([]byte, error) {
-	return []byte(id.String()), nil
+	return id.AppendString(make([]byte, 0, cb58MaxLen)), nil
+}
+
+// AppendText appends the String form of this id to [b].
+func (id ShortID) AppendText(b []byte) ([]byte, error) {
+	return id.AppendString(b), nil
+}
+
+// MarshalBinary returns the raw 20 bytes of this id.
+func (id ShortID) MarshalBinary() ([]byte, error) {
+	return id.AppendBinary(make([]byte, 0, ShortIDLen))
+}
+
+// AppendBinary appends the raw 20 bytes of this id to [b].
+func (id ShortID) AppendBinary(b []byte) ([]byte, error) {
+	return append(b, id[:]...), nil
+}
+
+// UnmarshalBinary is the inverse of MarshalBinary. The input must be exactly
+// ShortIDLen bytes.
+func (id *ShortID) UnmarshalBinary(b []byte) error {
+	parsed, err := ToShortID(b)
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
 }
 
 func (id ShortID) Compare(other ShortID) int {
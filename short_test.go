@@ -0,0 +1,52 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShortIDUnmarshalText(t *testing.T) {
+	id := ShortID{'a', 'v', 'a', ' ', 'l', 'a', 'b', 's'}
+	tests := []struct {
+		label       string
+		in          string
+		out         ShortID
+		expectedErr error
+	}{
+		{"unquoted", "9tLMkeWFhWXd8QZc4rSiS5meuVXF5kRsz", id, nil},
+		{"quoted", `"9tLMkeWFhWXd8QZc4rSiS5meuVXF5kRsz"`, id, nil},
+		{"null", "null", ShortID{24}, nil},
+		{"empty", "", ShortID{24}, errMissingQuotes},
+		{"missing end quote", `"9tLMkeWFhWXd8QZc4rSiS5meuVXF5kRsz`, ShortID{24}, errMissingQuotes},
+	}
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			require := require.New(t)
+
+			foo := ShortID{24}
+			err := foo.UnmarshalText([]byte(tt.in))
+			require.ErrorIs(err, tt.expectedErr)
+			require.Equal(tt.out, foo)
+		})
+	}
+}
+
+func TestShortIDMapMarshalling(t *testing.T) {
+	require := require.New(t)
+
+	originalMap := map[ShortID]int{
+		{'e', 'v', 'a', ' ', 'l', 'a', 'b', 's'}: 1,
+		{'a', 'v', 'a', ' ', 'l', 'a', 'b', 's'}: 2,
+	}
+	mapJSON, err := json.Marshal(originalMap)
+	require.NoError(err)
+
+	var unmarshalledMap map[ShortID]int
+	require.NoError(json.Unmarshal(mapJSON, &unmarshalledMap))
+	require.Equal(originalMap, unmarshalledMap)
+}
@@ -0,0 +1,219 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+// database/sql support.
+//
+// ID, ShortID, NodeID and TypedNodeID store their raw bytes (BYTEA / BLOB)
+// and scan them back with the same length checks as ToID, ToShortID, ToNodeID
+// and ParseTypedNodeID. TextID, TextShortID and TextNodeID are opt-in
+// wrappers that store the CB58 String form instead, for TEXT columns.
+//
+// Scanning NULL into any of these types is an error: a NULL column must be
+// read through Nullable, which maps NULL to the type's zero value (Empty,
+// ShortEmpty, EmptyNodeID, ...) and writes the zero value back as NULL.
+
+var (
+	_ driver.Valuer = ID{}
+	_ driver.Valuer = ShortID{}
+	_ driver.Valuer = NodeID{}
+	_ driver.Valuer = TypedNodeID{}
+	_ driver.Valuer = TextID{}
+	_ driver.Valuer = TextShortID{}
+	_ driver.Valuer = TextNodeID{}
+	_ driver.Valuer = Nullable[ID]{}
+
+	_ sql.Scanner = (*ID)(nil)
+	_ sql.Scanner = (*ShortID)(nil)
+	_ sql.Scanner = (*NodeID)(nil)
+	_ sql.Scanner = (*TypedNodeID)(nil)
+	_ sql.Scanner = (*TextID)(nil)
+	_ sql.Scanner = (*TextShortID)(nil)
+	_ sql.Scanner = (*TextNodeID)(nil)
+	_ sql.Scanner = (*Nullable[ID])(nil)
+
+	errSQLNull = errors.New("cannot scan NULL, use Nullable")
+	errSQLType = errors.New("unsupported SQL source type")
+)
+
+// Value implements driver.Valuer by returning the raw 32 bytes.
+func (id ID) Value() (driver.Value, error) {
+	return id[:], nil
+}
+
+// Scan implements sql.Scanner for raw 32-byte values.
+func (id *ID) Scan(src any) error {
+	b, err := sqlBytes(src)
+	if err != nil {
+		return err
+	}
+	*id, err = ToID(b)
+	return err
+}
+
+// Value implements driver.Valuer by returning the raw 20 bytes.
+func (id ShortID) Value() (driver.Value, error) {
+	return id[:], nil
+}
+
+// Scan implements sql.Scanner for raw 20-byte values.
+func (id *ShortID) Scan(src any) error {
+	b, err := sqlBytes(src)
+	if err != nil {
+		return err
+	}
+	*id, err = ToShortID(b)
+	return err
+}
+
+// Value implements driver.Valuer by returning the raw 20 bytes.
+func (id NodeID) Value() (driver.Value, error) {
+	return id[:], nil
+}
+
+// Scan implements sql.Scanner for raw 20-byte values.
+func (id *NodeID) Scan(src any) error {
+	b, err := sqlBytes(src)
+	if err != nil {
+		return err
+	}
+	*id, err = ToNodeID(b)
+	return err
+}
+
+// Value implements driver.Valuer by returning the 21-byte wire form. A
+// TypedNodeID with an unknown scheme is refused, matching NewTypedNodeID.
+func (t TypedNodeID) Value() (driver.Value, error) {
+	return t.MarshalBinary()
+}
+
+// Scan implements sql.Scanner for the 21-byte wire form.
+func (t *TypedNodeID) Scan(src any) error {
+	b, err := sqlBytes(src)
+	if err != nil {
+		return err
+	}
+	*t, err = ParseTypedNodeID(b)
+	return err
+}
+
+// TextID is an ID stored in SQL as its CB58 String form.
+type TextID ID
+
+// Value implements driver.Valuer.
+func (id TextID) Value() (driver.Value, error) {
+	return ID(id).String(), nil
+}
+
+// Scan implements sql.Scanner using FromString.
+func (id *TextID) Scan(src any) error {
+	s, err := sqlText(src)
+	if err != nil {
+		return err
+	}
+	parsed, err := FromString(s)
+	*id = TextID(parsed)
+	return err
+}
+
+// TextShortID is a ShortID stored in SQL as its CB58 String form.
+type TextShortID ShortID
+
+// Value implements driver.Valuer.
+func (id TextShortID) Value() (driver.Value, error) {
+	return ShortID(id).String(), nil
+}
+
+// Scan implements sql.Scanner using ShortFromString.
+func (id *TextShortID) Scan(src any) error {
+	s, err := sqlText(src)
+	if err != nil {
+		return err
+	}
+	parsed, err := ShortFromString(s)
+	*id = TextShortID(parsed)
+	return err
+}
+
+// TextNodeID is a NodeID stored in SQL as its "NodeID-<cb58>" String form.
+type TextNodeID NodeID
+
+// Value implements driver.Valuer.
+func (id TextNodeID) Value() (driver.Value, error) {
+	return NodeID(id).String(), nil
+}
+
+// Scan implements sql.Scanner using NodeIDFromString.
+func (id *TextNodeID) Scan(src any) error {
+	s, err := sqlText(src)
+	if err != nil {
+		return err
+	}
+	parsed, err := NodeIDFromString(s)
+	*id = TextNodeID(parsed)
+	return err
+}
+
+// SQLID is the set of identifier types that Nullable can wrap.
+type SQLID interface {
+	ID | ShortID | NodeID | TypedNodeID | TextID | TextShortID | TextNodeID
+	driver.Valuer
+}
+
+// Nullable maps SQL NULL to the zero value of T and back. Scanning NULL sets
+// V to the zero value; a zero V is written as NULL. Every other value is
+// delegated to T.
+type Nullable[T SQLID] struct {
+	V T
+}
+
+// Value implements driver.Valuer.
+func (n Nullable[T]) Value() (driver.Value, error) {
+	var zero T
+	if n.V == zero {
+		return nil, nil
+	}
+	return n.V.Value()
+}
+
+// Scan implements sql.Scanner.
+func (n *Nullable[T]) Scan(src any) error {
+	if src == nil {
+		var zero T
+		n.V = zero
+		return nil
+	}
+	return any(&n.V).(sql.Scanner).Scan(src)
+}
+
+func sqlBytes(src any) ([]byte, error) {
+	switch src := src.(type) {
+	case []byte:
+		return src, nil
+	case nil:
+		return nil, errSQLNull
+	default:
+		return nil, fmt.Errorf("%w: %T", errSQLType, src)
+	}
+}
+
+func sqlText(src any) (string, error) {
+	switch src := src.(type) {
+	case string:
+		return src, nil
+	case []byte:
+		return string(src), nil
+	case nil:
+		return "", errSQLNull
+	default:
+		return "", fmt.Errorf("%w: %T", errSQLType, src)
+	}
+}
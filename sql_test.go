@@ -0,0 +1,144 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/luxfi/crypto/hash"
+)
+
+func TestSQLRawRoundTrip(t *testing.T) {
+	typed := TypedNodeID{Scheme: NodeIDSchemeMLDSA65, NodeID: NodeID{7}}
+	tests := []struct {
+		name  string
+		value driver.Valuer
+		dst   sql.Scanner
+	}{
+		{"ID", ID{1, 2, 3}, new(ID)},
+		{"ShortID", ShortID{4, 5, 6}, new(ShortID)},
+		{"NodeID", NodeID{7, 8, 9}, new(NodeID)},
+		{"TypedNodeID", typed, new(TypedNodeID)},
+		{"TextID", TextID(XChainID), new(TextID)},
+		{"TextShortID", TextShortID{4, 5, 6}, new(TextShortID)},
+		{"TextNodeID", TextNodeID{7, 8, 9}, new(TextNodeID)},
+		{"Nullable", Nullable[NodeID]{V: NodeID{1}}, new(Nullable[NodeID])},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			v, err := tt.value.Value()
+			require.NoError(err)
+			require.True(driver.IsValue(v))
+			require.NoError(tt.dst.Scan(v))
+			require.Equal(tt.value, driver.Valuer(deref(tt.dst)))
+		})
+	}
+}
+
+// deref returns the value a Scanner under test points to.
+func deref(s sql.Scanner) driver.Valuer {
+	switch s := s.(type) {
+	case *ID:
+		return *s
+	case *ShortID:
+		return *s
+	case *NodeID:
+		return *s
+	case *TypedNodeID:
+		return *s
+	case *TextID:
+		return *s
+	case *TextShortID:
+		return *s
+	case *TextNodeID:
+		return *s
+	case *Nullable[NodeID]:
+		return *s
+	default:
+		panic("unexpected scanner")
+	}
+}
+
+func TestSQLValueForms(t *testing.T) {
+	require := require.New(t)
+
+	id := ID{1}
+	v, err := id.Value()
+	require.NoError(err)
+	require.Equal(id[:], v)
+
+	v, err = TextID(id).Value()
+	require.NoError(err)
+	require.Equal(id.String(), v)
+
+	v, err = TextNodeID{1}.Value()
+	require.NoError(err)
+	require.Equal(NodeID{1}.String(), v)
+
+	_, err = TypedNodeID{}.Value()
+	require.ErrorIs(err, ErrNodeIDSchemeInvalid)
+}
+
+func TestSQLScanErrors(t *testing.T) {
+	require := require.New(t)
+
+	var id ID
+	require.ErrorIs(id.Scan(nil), errSQLNull)
+	require.ErrorIs(id.Scan(int64(1)), errSQLType)
+	require.ErrorIs(id.Scan("not bytes"), errSQLType)
+	require.ErrorIs(id.Scan(make([]byte, IDLen-1)), hash.ErrInvalidHashLen)
+
+	var short ShortID
+	require.ErrorIs(short.Scan(make([]byte, IDLen)), hash.ErrInvalidHashLen)
+
+	var nodeID NodeID
+	require.ErrorIs(nodeID.Scan(nil), errSQLNull)
+	require.ErrorIs(nodeID.Scan(make([]byte, NodeIDLen+1)), hash.ErrInvalidHashLen)
+
+	var typed TypedNodeID
+	require.ErrorIs(typed.Scan(make([]byte, NodeIDLen)), ErrTypedNodeIDLen)
+	require.ErrorIs(typed.Scan(make([]byte, TypedNodeIDLen)), ErrNodeIDSchemeUnknown)
+
+	var text TextID
+	require.ErrorIs(text.Scan(nil), errSQLNull)
+	require.Error(text.Scan("not cb58")) //nolint:forbidigo // cb58 errors are wrapped
+
+	var textNodeID TextNodeID
+	require.Error(textNodeID.Scan([]byte("missing-prefix"))) //nolint:forbidigo // prefix error is not exported
+}
+
+func TestSQLNullable(t *testing.T) {
+	require := require.New(t)
+
+	v, err := Nullable[ID]{}.Value()
+	require.NoError(err)
+	require.Nil(v)
+
+	v, err = Nullable[TypedNodeID]{}.Value()
+	require.NoError(err)
+	require.Nil(v)
+
+	v, err = Nullable[TextShortID]{V: TextShortID{1}}.Value()
+	require.NoError(err)
+	require.Equal(ShortID{1}.String(), v)
+
+	n := Nullable[ShortID]{V: ShortID{1}}
+	require.NoError(n.Scan(nil))
+	require.Equal(ShortEmpty, n.V)
+
+	nodeID := Nullable[NodeID]{V: NodeID{1}}
+	require.NoError(nodeID.Scan(nil))
+	require.Equal(EmptyNodeID, nodeID.V)
+
+	id := Nullable[ID]{}
+	require.NoError(id.Scan(XChainID[:]))
+	require.Equal(XChainID, id.V)
+	require.ErrorIs(id.Scan([]byte{1}), hash.ErrInvalidHashLen)
+}
@@ -0,0 +1,245 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"math/bits"
+	"sync"
+	"sync/atomic"
+)
+
+// defaultStringCacheShards is the shard count used when
+// StringCacheConfig.Shards is zero.
+const defaultStringCacheShards = 16
+
+var (
+	errInvalidCacheSize  = errors.New("cache size must be positive")
+	errInvalidShardCount = errors.New("shard count must not be negative")
+	errShardsExceedSize  = errors.New("shard count must not exceed cache size")
+)
+
+var (
+	// globalStringCache is the cache installed by SetStringCache, or nil.
+	globalStringCache atomic.Pointer[StringCache]
+
+	stringCacheHashSeed = maphash.MakeSeed()
+)
+
+// SetStringCache makes ID.String, ShortID.String and NodeID.String read
+// through [c]. Passing nil disables caching, which is the default. Native
+// chain IDs never go through the cache; NativeChainString is already free.
+func SetStringCache(c *StringCache) {
+	globalStringCache.Store(c)
+}
+
+// StringCacheConfig configures a StringCache.
+type StringCacheConfig struct {
+	// Size is the maximum number of strings held across all shards.
+	Size int
+
+	// Shards is the number of independently locked shards. It is rounded
+	// up to a power of two and must not exceed Size; zero selects 16, or
+	// the largest power of two not above Size if that is smaller. More shards
+	// reduce lock contention between goroutines stringifying different
+	// IDs.
+	Shards int
+}
+
+// StringCacheStats is a point-in-time snapshot of a StringCache's counters.
+type StringCacheStats struct {
+	Hits   uint64
+	Misses uint64
+	// Len is the number of cached strings.
+	Len int
+}
+
+// StringCache is a bounded, sharded LRU cache of the String forms of IDs,
+// ShortIDs and NodeIDs. It is safe for concurrent use.
+//
+// A cache can be queried directly through its ID, ShortID and NodeID
+// methods, or installed with SetStringCache so that the String methods of
+// those types use it.
+type StringCache struct {
+	// mask selects a shard from a key hash; len(shards) is a power of two.
+	mask   uint64
+	shards []stringCacheShard
+}
+
+// NewStringCache returns an empty cache configured by [config].
+func NewStringCache(config StringCacheConfig) (*StringCache, error) {
+	if config.Size <= 0 {
+		return nil, fmt.Errorf("%w: %d", errInvalidCacheSize, config.Size)
+	}
+	if config.Shards < 0 {
+		return nil, fmt.Errorf("%w: %d", errInvalidShardCount, config.Shards)
+	}
+
+	numShards := 1 << bits.Len(uint(config.Shards-1))
+	if config.Shards == 0 {
+		numShards = defaultStringCacheShards
+		for numShards > config.Size {
+			numShards /= 2
+		}
+	}
+	if numShards > config.Size {
+		return nil, fmt.Errorf("%w: %d shards, size %d", errShardsExceedSize, numShards, config.Size)
+	}
+
+	c := &StringCache{
+		mask:   uint64(numShards - 1),
+		shards: make([]stringCacheShard, numShards),
+	}
+	// Spread the remainder so the shard capacities sum to exactly Size.
+	for i := range c.shards {
+		size := config.Size / numShards
+		if i < config.Size%numShards {
+			size++
+		}
+		c.shards[i] = stringCacheShard{
+			size:    size,
+			entries: make(map[stringCacheKey]*list.Element, size),
+		}
+	}
+	return c, nil
+}
+
+// ID returns id.String(), from the cache if possible.
+func (c *StringCache) ID(id ID) string {
+	if nativeStr := NativeChainString(id); nativeStr != "" {
+		return nativeStr
+	}
+	k := stringCacheKey{kind: stringCacheKindID, id: id}
+	return c.getOrEncode(k, id[:], "")
+}
+
+// ShortID returns id.String(), from the cache if possible.
+func (c *StringCache) ShortID(id ShortID) string {
+	k := stringCacheKey{kind: stringCacheKindShortID}
+	copy(k.id[:], id[:])
+	return c.getOrEncode(k, id[:], "")
+}
+
+// NodeID returns id.String(), from the cache if possible.
+func (c *StringCache) NodeID(id NodeID) string {
+	k := stringCacheKey{kind: stringCacheKindNodeID}
+	copy(k.id[:], id[:])
+	return c.getOrEncode(k, id[:], NodeIDPrefix)
+}
+
+// Stats returns the hit and miss counters and the current length, summed
+// over all shards.
+func (c *StringCache) Stats() StringCacheStats {
+	var stats StringCacheStats
+	for i := range c.shards {
+		s := &c.shards[i]
+		s.lock.Lock()
+		stats.Hits += s.hits
+		stats.Misses += s.misses
+		stats.Len += s.lru.Len()
+		s.lock.Unlock()
+	}
+	return stats
+}
+
+// Len returns the number of cached strings.
+func (c *StringCache) Len() int {
+	return c.Stats().Len
+}
+
+// Purge drops every cached string. The hit and miss counters are kept.
+func (c *StringCache) Purge() {
+	for i := range c.shards {
+		s := &c.shards[i]
+		s.lock.Lock()
+		clear(s.entries)
+		s.lru.Init()
+		s.lock.Unlock()
+	}
+}
+
+// getOrEncode returns the cached string for [k], computing it as [prefix]
+// followed by the CB58 encoding of [payload] on a miss. The encoding runs
+// outside the shard lock.
+func (c *StringCache) getOrEncode(k stringCacheKey, payload []byte, prefix string) string {
+	s := &c.shards[maphash.Comparable(stringCacheHashSeed, k)&c.mask]
+	if str, ok := s.get(k); ok {
+		return str
+	}
+
+	var buf [len(NodeIDPrefix) + cb58MaxLen]byte
+	str := string(appendCB58(append(buf[:0], prefix...), payload))
+	s.put(k, str)
+	return str
+}
+
+const (
+	stringCacheKindID uint8 = iota
+	stringCacheKindShortID
+	stringCacheKindNodeID
+)
+
+// stringCacheKey distinguishes the three ID types, since a ShortID and a
+// NodeID with the same bytes have different String forms. Shorter IDs are
+// zero-padded.
+type stringCacheKey struct {
+	kind uint8
+	id   ID
+}
+
+type stringCacheEntry struct {
+	key stringCacheKey
+	str string
+}
+
+// stringCacheShard is one independently locked LRU. The front of lru is the
+// most recently used entry.
+type stringCacheShard struct {
+	lock    sync.Mutex
+	size    int
+	entries map[stringCacheKey]*list.Element
+	lru     list.List
+	hits    uint64
+	misses  uint64
+}
+
+func (s *stringCacheShard) get(k stringCacheKey) (string, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	e, ok := s.entries[k]
+	if !ok {
+		s.misses++
+		return "", false
+	}
+	s.hits++
+	s.lru.MoveToFront(e)
+	return e.Value.(*stringCacheEntry).str, true
+}
+
+func (s *stringCacheShard) put(k stringCacheKey, str string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// Another goroutine may have filled the entry since the miss.
+	if e, ok := s.entries[k]; ok {
+		s.lru.MoveToFront(e)
+		return
+	}
+	if s.lru.Len() < s.size {
+		s.entries[k] = s.lru.PushFront(&stringCacheEntry{key: k, str: str})
+		return
+	}
+
+	// Reuse the least recently used element rather than allocating.
+	e := s.lru.Back()
+	entry := e.Value.(*stringCacheEntry)
+	delete(s.entries, entry.key)
+	entry.key, entry.str = k, str
+	s.entries[k] = e
+	s.lru.MoveToFront(e)
+}
@@ -0,0 +1,165 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStringCacheInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      StringCacheConfig
+		expectedErr error
+	}{
+		{"zero size", StringCacheConfig{}, errInvalidCacheSize},
+		{"negative size", StringCacheConfig{Size: -1}, errInvalidCacheSize},
+		{"negative shards", StringCacheConfig{Size: 1, Shards: -1}, errInvalidShardCount},
+		{"more shards than entries", StringCacheConfig{Size: 3, Shards: 3}, errShardsExceedSize},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewStringCache(tt.config)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestNewStringCacheShards(t *testing.T) {
+	require := require.New(t)
+
+	c, err := NewStringCache(StringCacheConfig{Size: 5})
+	require.NoError(err)
+	require.Len(c.shards, 4, "default shard count is capped by size and rounded up")
+
+	c, err = NewStringCache(StringCacheConfig{Size: 100, Shards: 5})
+	require.NoError(err)
+	require.Len(c.shards, 8)
+	total := 0
+	for i := range c.shards {
+		total += c.shards[i].size
+	}
+	require.Equal(100, total)
+}
+
+func TestStringCacheMatchesString(t *testing.T) {
+	require := require.New(t)
+
+	c, err := NewStringCache(StringCacheConfig{Size: 16, Shards: 1})
+	require.NoError(err)
+
+	id := ID{0x01, 31: 0x02}
+	short := ShortID{0x03}
+	nodeID := NodeID(short)
+	for range 2 {
+		require.Equal(id.String(), c.ID(id))
+		require.Equal(short.String(), c.ShortID(short))
+		require.Equal(nodeID.String(), c.NodeID(nodeID))
+	}
+	require.Equal(StringCacheStats{Hits: 3, Misses: 3, Len: 3}, c.Stats())
+}
+
+func TestStringCacheNativeChainsBypass(t *testing.T) {
+	require := require.New(t)
+
+	c, err := NewStringCache(StringCacheConfig{Size: 1})
+	require.NoError(err)
+
+	require.Equal(PChainIDStr, c.ID(PChainID))
+	require.Equal(StringCacheStats{}, c.Stats())
+}
+
+func TestStringCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	require := require.New(t)
+
+	c, err := NewStringCache(StringCacheConfig{Size: 2, Shards: 1})
+	require.NoError(err)
+
+	a, b, d := ID{0x01}, ID{0x02}, ID{0x03}
+	c.ID(a)
+	c.ID(b)
+	c.ID(a) // hit; b is now least recently used
+	c.ID(d) // evicts b
+	require.Equal(StringCacheStats{Hits: 1, Misses: 3, Len: 2}, c.Stats())
+
+	c.ID(a)
+	c.ID(d)
+	require.Equal(uint64(3), c.Stats().Hits)
+	c.ID(b)
+	require.Equal(uint64(4), c.Stats().Misses)
+
+	c.Purge()
+	require.Equal(StringCacheStats{Hits: 3, Misses: 4}, c.Stats())
+}
+
+func TestSetStringCache(t *testing.T) {
+	require := require.New(t)
+
+	c, err := NewStringCache(StringCacheConfig{Size: 8, Shards: 1})
+	require.NoError(err)
+	SetStringCache(c)
+	defer SetStringCache(nil)
+
+	id := ID{0x01}
+	expected := c.ID(id)
+	require.Equal(expected, id.String())
+	require.Equal(NodeIDPrefix+ShortID{0x02}.String(), NodeID{0x02}.String())
+	require.Equal(PChainIDStr, PChainID.String())
+	require.Equal(StringCacheStats{Hits: 1, Misses: 3, Len: 3}, c.Stats())
+
+	SetStringCache(nil)
+	require.Equal(expected, id.String())
+	require.Equal(uint64(1), c.Stats().Hits)
+}
+
+func TestStringCacheConcurrent(t *testing.T) {
+	require := require.New(t)
+
+	c, err := NewStringCache(StringCacheConfig{Size: 8, Shards: 2})
+	require.NoError(err)
+
+	var wg sync.WaitGroup
+	for g := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 100 {
+				id := ID{byte(g), byte(i % 16)}
+				if c.ID(id) != id.String() {
+					panic("cached string mismatch")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := c.Stats()
+	require.Equal(uint64(800), stats.Hits+stats.Misses)
+	require.Equal(8, stats.Len)
+}
+
+func BenchmarkStringCache(b *testing.B) {
+	c, err := NewStringCache(StringCacheConfig{Size: 1024})
+	if err != nil {
+		b.Fatal(err)
+	}
+	id := ID{0x01, 31: 0x02}
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			_ = id.String()
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		b.RunParallel(func(pb *testing.PB) {
+			for pb.Next() {
+				_ = c.ID(id)
+			}
+		})
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// UniqueBag maps each ID to the set of voter indices that reported it. A
+// voter is counted at most once per ID no matter how often it is added.
+type UniqueBag map[ID]Bits
+
+func (b *UniqueBag) init() {
+	if *b == nil {
+		*b = make(map[ID]Bits, minBagSize)
+	}
+}
+
+// Add records that voter [setID] reported each of [idSet].
+func (b *UniqueBag) Add(setID uint, idSet ...ID) {
+	bs := NewBits(setID)
+	for _, id := range idSet {
+		b.UnionSet(id, bs)
+	}
+}
+
+// UnionSet adds every voter in [set] to the voters of [id].
+func (b *UniqueBag) UnionSet(id ID, set Bits) {
+	b.init()
+
+	previousSet := (*b)[id]
+	previousSet.Union(set)
+	(*b)[id] = previousSet
+}
+
+// DifferenceSet removes every voter in [set] from the voters of [id]. An ID
+// left with no voters is removed from the bag.
+func (b *UniqueBag) DifferenceSet(id ID, set Bits) {
+	previousSet, ok := (*b)[id]
+	if !ok {
+		return
+	}
+	previousSet.Difference(set)
+	if previousSet.Len() == 0 {
+		delete(*b, id)
+		return
+	}
+	(*b)[id] = previousSet
+}
+
+// Difference removes, for every ID in [diff], the voters recorded for it in
+// [diff].
+func (b *UniqueBag) Difference(diff *UniqueBag) {
+	for id, set := range *diff {
+		b.DifferenceSet(id, set)
+	}
+}
+
+// GetSet returns a copy of the voters of [id].
+func (b *UniqueBag) GetSet(id ID) Bits {
+	return (*b)[id].Clone()
+}
+
+// RemoveSet removes [id] and all of its voters from the bag.
+func (b *UniqueBag) RemoveSet(id ID) {
+	delete(*b, id)
+}
+
+// List returns the IDs in the bag in unspecified order.
+func (b *UniqueBag) List() []ID {
+	return slices.Collect(maps.Keys(*b))
+}
+
+// Bag returns a Bag in which each ID is counted once per voter, with the
+// provided threshold.
+func (b *UniqueBag) Bag(threshold int) Bag[ID] {
+	var bag Bag[ID]
+	bag.SetThreshold(threshold)
+	for id, set := range *b {
+		bag.AddCount(id, set.Len())
+	}
+	return bag
+}
+
+// Filter returns the IDs, with copies of their voters, that match [id] from
+// bit [start] to bit [end] (non-inclusive). Bit indices follow EqualSubset.
+func (b *UniqueBag) Filter(start, end int, id ID) UniqueBag {
+	newBag := make(UniqueBag, len(*b))
+	for vote, set := range *b {
+		if EqualSubset(start, end, id, vote) {
+			newBag[vote] = set.Clone()
+		}
+	}
+	return newBag
+}
+
+// Clear empties the bag.
+func (b *UniqueBag) Clear() {
+	clear(*b)
+}
+
+func (b *UniqueBag) String() string {
+	sb := strings.Builder{}
+
+	sb.WriteString(fmt.Sprintf("UniqueBag: (Size = %d)", len(*b)))
+	for _, id := range SortedList(SetOf(b.List()...)) {
+		sb.WriteString(fmt.Sprintf("\n    ID[%s]: Members = %s", id, (*b)[id]))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBits(t *testing.T) {
+	require := require.New(t)
+
+	var b Bits
+	require.Zero(b.Len())
+	require.Zero(b.BitLen())
+	require.Equal("0x0", b.String())
+
+	b.Add(0)
+	b.Add(2)
+	b.Add(64)
+	require.Equal(3, b.Len())
+	require.Equal(65, b.BitLen())
+	require.True(b.Contains(64))
+	require.False(b.Contains(1))
+	require.False(b.Contains(1000))
+	require.Equal([]uint{0, 2, 64}, b.List())
+	require.Equal("0x10000000000000005", b.String())
+
+	b.Remove(64)
+	require.True(b.Equals(NewBits(0, 2)))
+	require.Equal(3, b.BitLen())
+
+	other := NewBits(2, 3, 128)
+	union := b.Clone()
+	union.Union(other)
+	require.True(union.Equals(NewBits(0, 2, 3, 128)))
+
+	intersection := b.Clone()
+	intersection.Intersection(other)
+	require.True(intersection.Equals(NewBits(2)))
+
+	difference := other.Clone()
+	difference.Difference(NewBits(128))
+	require.True(difference.Equals(NewBits(2, 3)))
+
+	// The original sets are untouched by operations on their clones.
+	require.True(b.Equals(NewBits(0, 2)))
+	require.True(other.Equals(NewBits(2, 3, 128)))
+}
+
+func TestUniqueBag(t *testing.T) {
+	require := require.New(t)
+
+	id1 := ID{1}
+	id2 := ID{2}
+
+	var ub UniqueBag
+	ub.Add(0, id1)
+	ub.Add(0, id1) // voters are counted once
+	ub.Add(1, id1, id2)
+	require.ElementsMatch([]ID{id1, id2}, ub.List())
+	require.True(ub.GetSet(id1).Equals(NewBits(0, 1)))
+	require.True(ub.GetSet(id2).Equals(NewBits(1)))
+
+	// GetSet returns a copy.
+	set := ub.GetSet(id1)
+	set.Add(5)
+	require.False(ub.GetSet(id1).Contains(5))
+
+	bag := ub.Bag(2)
+	require.Equal(2, bag.Count(id1))
+	require.Equal(1, bag.Count(id2))
+	require.Equal(SetOf(id1), bag.Threshold())
+
+	ub.UnionSet(id2, NewBits(3, 4))
+	require.True(ub.GetSet(id2).Equals(NewBits(1, 3, 4)))
+
+	ub.DifferenceSet(id2, NewBits(1))
+	require.True(ub.GetSet(id2).Equals(NewBits(3, 4)))
+
+	var diff UniqueBag
+	diff.Add(3, id2)
+	diff.Add(4, id2)
+	ub.Difference(&diff)
+	require.Equal([]ID{id1}, ub.List())
+
+	ub.RemoveSet(id1)
+	require.Empty(ub)
+}
+
+func TestUniqueBagFilter(t *testing.T) {
+	require := require.New(t)
+
+	id1 := ID{0x00}
+	id2 := ID{0x01}
+	id3 := ID{0x02}
+
+	var ub UniqueBag
+	ub.Add(0, id1, id2, id3)
+	ub.Add(1, id2)
+
+	filtered := ub.Filter(0, 1, id2)
+	require.ElementsMatch([]ID{id2}, filtered.List())
+	require.True(filtered.GetSet(id2).Equals(NewBits(0, 1)))
+
+	filtered = ub.Filter(1, 2, id1)
+	require.ElementsMatch([]ID{id1, id2}, filtered.List())
+
+	// The filtered bag does not share voter sets with the original.
+	filtered.UnionSet(id1, NewBits(7))
+	require.False(ub.GetSet(id1).Contains(7))
+}
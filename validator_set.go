@@ -0,0 +1,310 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// validator_set.go — Merkle commitment to a validator set.
+//
+// A ValidatorSetCommitment binds an ordered list of validators, each a
+// (TypedNodeID, FullDigest, weight) triple, to one 48-byte root. Light
+// clients and bridges hold the root (e.g. from genesis) and check a single
+// validator with a ValidatorSetProof instead of downloading the set.
+//
+//	leaf  = SHAKE256-384("VALIDATOR_SET_LEAF_V1" || scheme || NodeID ||
+//	                     FullDigest || weight)
+//	node  = SHAKE256-384("VALIDATOR_SET_NODE_V1" || left || right)
+//	root  = SHAKE256-384("VALIDATOR_SET_ROOT_V1" || count || totalWeight ||
+//	                     tree)
+//
+// Every field is framed with SP 800-185 left_encode, as in DeriveMLDSA,
+// and integers are 8-byte big-endian. Leaves are ordered by
+// TypedNodeID.Compare. A node without a sibling is promoted to the next
+// level unchanged rather than paired with itself, so no two distinct sets
+// share a tree. The count and total weight are bound into the root, so a
+// proof cannot lie about the size of the set it was cut from.
+
+package ids
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"slices"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	validatorSetLeafDomain = "VALIDATOR_SET_LEAF_V1"
+	validatorSetNodeDomain = "VALIDATOR_SET_NODE_V1"
+	validatorSetRootDomain = "VALIDATOR_SET_ROOT_V1"
+)
+
+var (
+	// ErrValidatorSetEmpty — a commitment was requested over no validators.
+	ErrValidatorSetEmpty = errors.New("ids: validator set is empty")
+
+	// ErrValidatorSetDuplicate — two entries share a NodeID, under the
+	// same or different schemes.
+	ErrValidatorSetDuplicate = errors.New("ids: duplicate validator in set")
+
+	// ErrValidatorSetEntryInvalid — an entry has a dead scheme, zero
+	// weight, a FullDigest that does not match its NodeID, or pushes the
+	// total weight past math.MaxUint64.
+	ErrValidatorSetEntryInvalid = errors.New("ids: invalid validator set entry")
+
+	// ErrValidatorNotInSet — a proof was requested for a validator that is
+	// not in the set.
+	ErrValidatorNotInSet = errors.New("ids: validator is not in set")
+
+	// ErrValidatorSetProofInvalid — a proof does not verify against the
+	// supplied root and entry.
+	ErrValidatorSetProofInvalid = errors.New("ids: invalid validator set proof")
+)
+
+// ValidatorSetHash is a 48-byte SHAKE256-384 node of a validator-set tree,
+// including its root.
+type ValidatorSetHash [FullDigestLen]byte
+
+// String returns the hex encoding of this hash.
+func (h ValidatorSetHash) String() string {
+	return hex.EncodeToString(h[:])
+}
+
+// ValidatorSetEntry is one validator in a ValidatorSetCommitment.
+type ValidatorSetEntry struct {
+	NodeID TypedNodeID
+	// Digest is the full derivation digest of NodeID. For schemes with a
+	// public-key derivation, NodeID.NodeID must equal Digest[:NodeIDLen].
+	Digest FullDigest
+	Weight uint64
+}
+
+// leafHash returns the leaf hash of this entry.
+func (e ValidatorSetEntry) leafHash() ValidatorSetHash {
+	var weight [8]byte
+	binary.BigEndian.PutUint64(weight[:], e.Weight)
+	return validatorSetHash(
+		validatorSetLeafDomain,
+		[]byte{byte(e.NodeID.Scheme)},
+		e.NodeID.NodeID[:],
+		e.Digest[:],
+		weight[:],
+	)
+}
+
+// ValidatorSetCommitment is an immutable Merkle commitment to a validator
+// set. See the file header for the hashing rules.
+type ValidatorSetCommitment struct {
+	entries     []ValidatorSetEntry
+	totalWeight uint64
+	// levels[0] holds the leaf hashes; the last level holds the tree root.
+	levels [][]ValidatorSetHash
+	root   ValidatorSetHash
+}
+
+// NewValidatorSetCommitment sorts [entries] by TypedNodeID and commits to
+// them. [entries] is not modified.
+//
+// Every entry must have a registered, live scheme and a non-zero weight,
+// and entries whose scheme derives NodeIDs from a public key must carry the
+// FullDigest the NodeID was truncated from. No two entries may share a
+// 20-byte NodeID, even under different schemes.
+func NewValidatorSetCommitment(entries []ValidatorSetEntry) (*ValidatorSetCommitment, error) {
+	if len(entries) == 0 {
+		return nil, ErrValidatorSetEmpty
+	}
+
+	sorted := slices.Clone(entries)
+	slices.SortFunc(sorted, func(a, b ValidatorSetEntry) int {
+		return a.NodeID.Compare(b.NodeID)
+	})
+
+	var (
+		seen        = NewSet[NodeID](len(sorted))
+		totalWeight uint64
+		leaves      = make([]ValidatorSetHash, len(sorted))
+	)
+	for i, e := range sorted {
+		if err := validateValidatorSetEntry(e); err != nil {
+			return nil, err
+		}
+		if seen.Contains(e.NodeID.NodeID) {
+			return nil, fmt.Errorf("%w: %s", ErrValidatorSetDuplicate, e.NodeID.NodeID)
+		}
+		seen.Add(e.NodeID.NodeID)
+
+		var carry uint64
+		totalWeight, carry = bits.Add64(totalWeight, e.Weight, 0)
+		if carry != 0 {
+			return nil, fmt.Errorf("%w: total weight overflows at %s", ErrValidatorSetEntryInvalid, e.NodeID)
+		}
+		leaves[i] = e.leafHash()
+	}
+
+	levels := [][]ValidatorSetHash{leaves}
+	for level := leaves; len(level) > 1; {
+		next := make([]ValidatorSetHash, 0, (len(level)+1)/2)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 == len(level) {
+				next = append(next, level[i])
+				continue
+			}
+			next = append(next, validatorSetNodeHash(level[i], level[i+1]))
+		}
+		levels = append(levels, next)
+		level = next
+	}
+
+	return &ValidatorSetCommitment{
+		entries:     sorted,
+		totalWeight: totalWeight,
+		levels:      levels,
+		root:        validatorSetRootHash(uint64(len(sorted)), totalWeight, levels[len(levels)-1][0]),
+	}, nil
+}
+
+func validateValidatorSetEntry(e ValidatorSetEntry) error {
+	if err := e.NodeID.Scheme.checkKnown(); err != nil {
+		return err
+	}
+	if e.Weight == 0 {
+		return fmt.Errorf("%w: %s has zero weight", ErrValidatorSetEntryInvalid, e.NodeID)
+	}
+	d, _ := nodeIDSchemes.active(e.NodeID.Scheme)
+	if d.Derive != nil && e.Digest.NodeID() != e.NodeID.NodeID {
+		return fmt.Errorf("%w: %s does not match its digest", ErrValidatorSetEntryInvalid, e.NodeID)
+	}
+	return nil
+}
+
+// Root returns the commitment root.
+func (c *ValidatorSetCommitment) Root() ValidatorSetHash {
+	return c.root
+}
+
+// Len returns the number of validators in the set.
+func (c *ValidatorSetCommitment) Len() int {
+	return len(c.entries)
+}
+
+// TotalWeight returns the sum of all validator weights.
+func (c *ValidatorSetCommitment) TotalWeight() uint64 {
+	return c.totalWeight
+}
+
+// Entries returns the validators in commitment order.
+func (c *ValidatorSetCommitment) Entries() []ValidatorSetEntry {
+	return slices.Clone(c.entries)
+}
+
+// Get returns the entry for [nodeID] and its index in commitment order.
+func (c *ValidatorSetCommitment) Get(nodeID TypedNodeID) (ValidatorSetEntry, int, bool) {
+	i, ok := slices.BinarySearchFunc(c.entries, nodeID, func(e ValidatorSetEntry, t TypedNodeID) int {
+		return e.NodeID.Compare(t)
+	})
+	if !ok {
+		return ValidatorSetEntry{}, 0, false
+	}
+	return c.entries[i], i, true
+}
+
+// Proof returns the inclusion proof for [nodeID], together with its entry,
+// or ErrValidatorNotInSet.
+func (c *ValidatorSetCommitment) Proof(nodeID TypedNodeID) (ValidatorSetEntry, ValidatorSetProof, error) {
+	e, index, ok := c.Get(nodeID)
+	if !ok {
+		return ValidatorSetEntry{}, ValidatorSetProof{}, fmt.Errorf("%w: %s", ErrValidatorNotInSet, nodeID)
+	}
+
+	proof := ValidatorSetProof{
+		Index:       uint64(index),
+		Total:       uint64(len(c.entries)),
+		TotalWeight: c.totalWeight,
+	}
+	for _, level := range c.levels[:len(c.levels)-1] {
+		if sibling := index ^ 1; sibling < len(level) {
+			proof.Path = append(proof.Path, level[sibling])
+		}
+		index /= 2
+	}
+	return e, proof, nil
+}
+
+// ValidatorSetProof proves that one entry is in the set committed to by a
+// root. Index is the entry's position in commitment order, Total and
+// TotalWeight are the size and weight of the whole set, and Path lists the
+// sibling hashes from the leaf up. Levels where the entry's node was
+// promoted contribute no sibling.
+type ValidatorSetProof struct {
+	Index       uint64
+	Total       uint64
+	TotalWeight uint64
+	Path        []ValidatorSetHash
+}
+
+// Verify returns nil if [entry] is in the set committed to by [root], and
+// ErrValidatorSetProofInvalid otherwise.
+func (p ValidatorSetProof) Verify(root ValidatorSetHash, entry ValidatorSetEntry) error {
+	if p.Total == 0 || p.Index >= p.Total || p.Total > math.MaxInt {
+		return fmt.Errorf("%w: index %d of %d", ErrValidatorSetProofInvalid, p.Index, p.Total)
+	}
+
+	var (
+		h     = entry.leafHash()
+		index = p.Index
+		path  = p.Path
+	)
+	for n := p.Total; n > 1; n = (n + 1) / 2 {
+		switch {
+		case index%2 == 1:
+			if len(path) == 0 {
+				return fmt.Errorf("%w: path too short", ErrValidatorSetProofInvalid)
+			}
+			h = validatorSetNodeHash(path[0], h)
+			path = path[1:]
+		case index+1 < n:
+			if len(path) == 0 {
+				return fmt.Errorf("%w: path too short", ErrValidatorSetProofInvalid)
+			}
+			h = validatorSetNodeHash(h, path[0])
+			path = path[1:]
+		}
+		index /= 2
+	}
+	if len(path) != 0 {
+		return fmt.Errorf("%w: path too long", ErrValidatorSetProofInvalid)
+	}
+	if validatorSetRootHash(p.Total, p.TotalWeight, h) != root {
+		return fmt.Errorf("%w: root mismatch", ErrValidatorSetProofInvalid)
+	}
+	return nil
+}
+
+func validatorSetNodeHash(left, right ValidatorSetHash) ValidatorSetHash {
+	return validatorSetHash(validatorSetNodeDomain, left[:], right[:])
+}
+
+func validatorSetRootHash(count, totalWeight uint64, tree ValidatorSetHash) ValidatorSetHash {
+	var buf [16]byte
+	binary.BigEndian.PutUint64(buf[:8], count)
+	binary.BigEndian.PutUint64(buf[8:], totalWeight)
+	return validatorSetHash(validatorSetRootDomain, buf[:8], buf[8:], tree[:])
+}
+
+// validatorSetHash is SHAKE256-384 over [domain] and [fields], each framed
+// with left_encode of its bit length.
+func validatorSetHash(domain string, fields ...[]byte) ValidatorSetHash {
+	h := sha3.NewShake256()
+	_, _ = h.Write(leftEncodeNodeID(uint64(len(domain)) * 8))
+	_, _ = h.Write([]byte(domain))
+	for _, f := range fields {
+		_, _ = h.Write(leftEncodeNodeID(uint64(len(f)) * 8))
+		_, _ = h.Write(f)
+	}
+
+	var out ValidatorSetHash
+	_, _ = h.Read(out[:])
+	return out
+}
@@ -0,0 +1,168 @@
+// Copyright (C) 2020-2026, Lux Industries Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package ids
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidatorSetCommitment_KnownAnswer pins the root of a three-entry
+// set covering ML-DSA, SLH-DSA and classical entries and an odd promotion.
+// The root was computed independently of this package with Python's
+// hashlib.shake_256.
+func TestValidatorSetCommitment_KnownAnswer(t *testing.T) {
+	require := require.New(t)
+
+	mldsa, mldsaDigest, err := TypedNodeIDFromMLDSA(NodeIDSchemeMLDSA65, ID{}, []byte("validator-0"))
+	require.NoError(err)
+	slhPubKey := make([]byte, 32)
+	for i := range slhPubKey {
+		slhPubKey[i] = byte(i)
+	}
+	slhdsa, slhdsaDigest, err := TypedNodeIDFromSLHDSA(NodeIDSchemeSLHDSASHAKE128s, ID{}, slhPubKey)
+	require.NoError(err)
+	var classicalDigest FullDigest
+	for i := range classicalDigest {
+		classicalDigest[i] = 0x11
+	}
+
+	c, err := NewValidatorSetCommitment([]ValidatorSetEntry{
+		{NodeID: TypedNodeID{Scheme: NodeIDSchemeSecp256k1, NodeID: NodeID{0x01}}, Digest: classicalDigest, Weight: 100},
+		{NodeID: slhdsa, Digest: slhdsaDigest, Weight: 300},
+		{NodeID: mldsa, Digest: mldsaDigest, Weight: 200},
+	})
+	require.NoError(err)
+	require.Equal(3, c.Len())
+	require.Equal(uint64(600), c.TotalWeight())
+	require.Equal(
+		"5e1d5a8a9a1d1f4bfece14790caf0203a7a3536523a9edcd149d4c68bc2588c3b823d9b4accc7bf46b8c2feae0eb6bef",
+		c.Root().String(),
+	)
+	require.Equal(mldsa, c.Entries()[0].NodeID)
+}
+
+func TestValidatorSetCommitment_Proofs(t *testing.T) {
+	for n := 1; n <= 17; n++ {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			require := require.New(t)
+
+			entries := testValidatorSetEntries(t, n)
+			c, err := NewValidatorSetCommitment(entries)
+			require.NoError(err)
+
+			// The root does not depend on input order.
+			shuffled := slices.Clone(entries)
+			rand.New(rand.NewSource(int64(n))).Shuffle(len(shuffled), func(i, j int) { //#nosec G404
+				shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+			})
+			c2, err := NewValidatorSetCommitment(shuffled)
+			require.NoError(err)
+			require.Equal(c.Root(), c2.Root())
+
+			for _, e := range entries {
+				got, proof, err := c.Proof(e.NodeID)
+				require.NoError(err)
+				require.Equal(e, got)
+				require.NoError(proof.Verify(c.Root(), e))
+
+				tampered := e
+				tampered.Weight++
+				require.ErrorIs(proof.Verify(c.Root(), tampered), ErrValidatorSetProofInvalid)
+
+				badTotal := proof
+				badTotal.Total++
+				require.ErrorIs(badTotal.Verify(c.Root(), e), ErrValidatorSetProofInvalid)
+
+				badWeight := proof
+				badWeight.TotalWeight++
+				require.ErrorIs(badWeight.Verify(c.Root(), e), ErrValidatorSetProofInvalid)
+
+				longPath := proof
+				longPath.Path = append(slices.Clone(proof.Path), ValidatorSetHash{})
+				require.ErrorIs(longPath.Verify(c.Root(), e), ErrValidatorSetProofInvalid)
+
+				if len(proof.Path) > 0 {
+					shortPath := proof
+					shortPath.Path = proof.Path[:len(proof.Path)-1]
+					require.ErrorIs(shortPath.Verify(c.Root(), e), ErrValidatorSetProofInvalid)
+				}
+				if n > 1 {
+					otherIndex := proof
+					otherIndex.Index = (proof.Index + 1) % proof.Total
+					require.ErrorIs(otherIndex.Verify(c.Root(), e), ErrValidatorSetProofInvalid)
+				}
+			}
+
+			_, _, err = c.Proof(TypedNodeID{Scheme: NodeIDSchemeSecp256k1, NodeID: NodeID{0xff}})
+			require.ErrorIs(err, ErrValidatorNotInSet)
+		})
+	}
+}
+
+func TestValidatorSetProof_VerifyRejectsMalformed(t *testing.T) {
+	require := require.New(t)
+
+	entries := testValidatorSetEntries(t, 2)
+	c, err := NewValidatorSetCommitment(entries)
+	require.NoError(err)
+
+	require.ErrorIs(ValidatorSetProof{}.Verify(c.Root(), entries[0]), ErrValidatorSetProofInvalid)
+	require.ErrorIs(ValidatorSetProof{Index: 2, Total: 2}.Verify(c.Root(), entries[0]), ErrValidatorSetProofInvalid)
+}
+
+func TestNewValidatorSetCommitment_Errors(t *testing.T) {
+	entries := testValidatorSetEntries(t, 2)
+	classical := ValidatorSetEntry{
+		NodeID: TypedNodeID{Scheme: NodeIDSchemeSecp256k1, NodeID: entries[0].NodeID.NodeID},
+		Weight: 1,
+	}
+	mismatched := entries[1]
+	mismatched.Digest[0] ^= 0xff
+	zeroWeight := entries[1]
+	zeroWeight.Weight = 0
+	heavy := entries[1]
+	heavy.Weight = math.MaxUint64
+
+	tests := []struct {
+		name        string
+		entries     []ValidatorSetEntry
+		expectedErr error
+	}{
+		{"empty", nil, ErrValidatorSetEmpty},
+		{"duplicate", []ValidatorSetEntry{entries[0], entries[0]}, ErrValidatorSetDuplicate},
+		{"duplicate NodeID under another scheme", []ValidatorSetEntry{entries[0], classical}, ErrValidatorSetDuplicate},
+		{"digest mismatch", []ValidatorSetEntry{entries[0], mismatched}, ErrValidatorSetEntryInvalid},
+		{"zero weight", []ValidatorSetEntry{entries[0], zeroWeight}, ErrValidatorSetEntryInvalid},
+		{"weight overflow", []ValidatorSetEntry{entries[0], heavy}, ErrValidatorSetEntryInvalid},
+		{"invalid scheme", []ValidatorSetEntry{{Weight: 1}}, ErrNodeIDSchemeInvalid},
+		{"unknown scheme", []ValidatorSetEntry{{NodeID: TypedNodeID{Scheme: 0x91}, Weight: 1}}, ErrNodeIDSchemeUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewValidatorSetCommitment(tt.entries)
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+// testValidatorSetEntries returns [n] ML-DSA-65 entries with weights 1..n.
+func testValidatorSetEntries(t *testing.T, n int) []ValidatorSetEntry {
+	entries := make([]ValidatorSetEntry, n)
+	for i := range entries {
+		typed, digest, err := TypedNodeIDFromMLDSA(NodeIDSchemeMLDSA65, ID{}, []byte(fmt.Sprintf("validator-%d", i)))
+		require.NoError(t, err)
+		entries[i] = ValidatorSetEntry{
+			NodeID: typed,
+			Digest: digest,
+			Weight: uint64(i + 1),
+		}
+	}
+	return entries
+}